@@ -1,25 +1,62 @@
 package adversary
 
 import (
-	"reflect"
-
 	"github.com/iotaledger/multivers-simulation/multiverse"
 	"github.com/iotaledger/multivers-simulation/network"
+	"github.com/iotaledger/multivers-simulation/singlenodeattacks"
 )
 
-type NodeInterface interface {
-	AssignColor(color multiverse.Color)
+// region Behavior /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Behavior changes a part of the behavior of an honest node, either by replacing one of its components or by adding
+// NodeHooks. Behaviors are applied before the node is set up.
+type Behavior func(node *multiverse.Node)
+
+// behaviors contains the behaviors that can be selected in AdversaryBehaviors.
+var behaviors = map[string]Behavior{
+	"ShiftingOpinion": ShiftingOpinion,
+	"SameOpinion":     SameOpinion,
+	"NoGossip":        NoGossip,
+	"NoRequests":      NoRequests,
+	"NoDoubleSpend":   NoDoubleSpend,
+	"NoIssuance":      NoIssuance,
+	"SelectiveGossip": SelectiveGossip,
+	"LazyTips":        LazyTips,
+	"Speedup":         Speedup,
+	"ParasiteChain":   NewParasiteChain,
+	"Balancing":       NewBalancingAttacker,
+	"Eclipse":         NewEclipseAttacker,
+	"CensorTips":      CensorTips,
+	"CensorScheduler": CensorScheduler,
+	"Spam":            NewSpammer,
+	"Timestamps":      Timestamps,
+	"Equivocation":    NewEquivocator,
+	"Sybil":           Sybil,
+	"Blowball":        singlenodeattacks.Blowball,
+}
+
+// RegisterBehavior makes a behavior available under the given name, so new attacks can be composed from the scenario
+// without new node types.
+func RegisterBehavior(name string, behavior Behavior) {
+	behaviors[name] = behavior
 }
 
-func CastAdversary(node network.Node) NodeInterface {
-	s := reflect.ValueOf(node)
-	switch s.Interface().(type) {
-	case *ShiftingOpinionNode:
-		return node.(*ShiftingOpinionNode)
-	case *SameOpinionNode:
-		return node.(*SameOpinionNode)
-	case *NoGossipNode:
-		return node.(*NoGossipNode)
+// NewAdversaryNode creates an honest node with the behaviors of the adversary group.
+func NewAdversaryNode(group *network.AdversaryGroup) network.Node {
+	node := multiverse.NewNode().(*multiverse.Node)
+	for _, name := range group.Behaviors {
+		behavior, exists := behaviors[name]
+		if !exists {
+			panic("invalid adversary behavior " + name)
+		}
+		behavior(node)
 	}
-	return nil
+	return node
+}
+
+// Speedup makes the node issue at AdversarySpeedup times its bandwidth regardless of the rate setter.
+func Speedup(node *multiverse.Node) {
+	node.Tangle().Hooks.RateSetterBypassed = true
 }
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
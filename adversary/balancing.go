@@ -0,0 +1,223 @@
+package adversary
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region BalancingAttacker ////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// balancingAttackers are all nodes with the 'Balancing' behavior.
+	balancingAttackers []*BalancingAttacker
+	// observedNetwork gives the omniscient balancing attackers access to the opinions of all nodes.
+	observedNetwork *network.Network
+)
+
+// BalancingAttackers returns all nodes with the 'Balancing' behavior.
+func BalancingAttackers() []*BalancingAttacker {
+	return balancingAttackers
+}
+
+// ObserveNetwork makes the opinions of all nodes of the network visible to the balancing attackers, which use them
+// instead of their local estimates if BalancingOmniscient is set.
+func ObserveNetwork(net *network.Network) {
+	observedNetwork = net
+}
+
+// BalancingAttacker tries to keep the network split between the two conflicting colors with the highest approval
+// weight. Instead of a data message, it issues a vote for each color and gossips every neighbor the vote for the color
+// the neighbor does not like, so the views of all neighbors are pushed towards a tie.
+type BalancingAttacker struct {
+	node *multiverse.Node
+
+	// lastVotes are the colors of the last booked messages of every issuer, the local estimate of their opinions
+	lastVotes map[network.PeerID]multiverse.Color
+	// voteColor is the color of the vote that is currently created
+	voteColor multiverse.Color
+	// voteReceivers are the neighbors every vote is gossiped to, until the vote was gossiped
+	voteReceivers map[multiverse.MessageID]*voteReceivers
+	// round alternates the order of the votes, so the neighbors do not always see the same color last
+	round      int
+	votes      int
+	splitVotes int
+
+	mutex sync.RWMutex
+}
+
+// NewBalancingAttacker adds the 'Balancing' behavior to the node.
+func NewBalancingAttacker(node *multiverse.Node) {
+	b := &BalancingAttacker{
+		node:          node,
+		lastVotes:     make(map[network.PeerID]multiverse.Color),
+		voteReceivers: make(map[multiverse.MessageID]*voteReceivers),
+	}
+	balancingAttackers = append(balancingAttackers, b)
+
+	hooks := node.Tangle().Hooks
+	hooks.IssuanceHandlers = append(hooks.IssuanceHandlers, b.issueVotes)
+	hooks.TipSelectors = append(hooks.TipSelectors, b.selectTips)
+	hooks.GossipFilters = append(hooks.GossipFilters, b.gossipAllowed)
+	node.Tangle().Booker.Events.MessageBooked.Attach(events.NewClosure(b.messageBooked))
+	node.Tangle().Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs multiverse.MessageIDs) {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		for messageID := range messageIDs {
+			delete(b.voteReceivers, messageID)
+		}
+	}))
+}
+
+// Node returns the node of the attacker.
+func (b *BalancingAttacker) Node() *multiverse.Node {
+	return b.node
+}
+
+// Votes returns the number of votes the attacker issued and the number of rounds in which its neighbors got votes for
+// different colors.
+func (b *BalancingAttacker) Votes() (votes, splitVotes int) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.votes, b.splitVotes
+}
+
+// issueVotes replaces a data message by a vote for each of the two conflicting colors, colored messages are issued as
+// usual.
+func (b *BalancingAttacker) issueVotes(payload multiverse.Color) bool {
+	if payload != multiverse.UndefinedColor {
+		return false
+	}
+	leading, trailing, conflicting := b.conflictingColors()
+	if !conflicting {
+		return false
+	}
+
+	receivers := map[multiverse.Color]map[network.PeerID]bool{
+		leading:  make(map[network.PeerID]bool),
+		trailing: make(map[network.PeerID]bool),
+	}
+	for neighbor := range b.node.Peer().Neighbors {
+		if b.opinion(neighbor) == trailing {
+			receivers[leading][neighbor] = true
+		} else {
+			receivers[trailing][neighbor] = true
+		}
+	}
+
+	voteColors := []multiverse.Color{leading, trailing}
+	if b.round++; b.round%2 == 0 {
+		voteColors[0], voteColors[1] = trailing, leading
+	}
+	votes := 0
+	for _, voteColor := range voteColors {
+		if len(receivers[voteColor]) == 0 {
+			continue
+		}
+		b.mutex.Lock()
+		b.voteColor = voteColor
+		b.mutex.Unlock()
+		message, ok := b.node.Tangle().MessageFactory.CreateMessage(false, multiverse.UndefinedColor)
+		b.mutex.Lock()
+		b.voteColor = multiverse.UndefinedColor
+		if ok {
+			b.voteReceivers[message.ID] = &voteReceivers{receivers: receivers[voteColor]}
+			b.votes++
+			votes++
+		}
+		b.mutex.Unlock()
+		if ok {
+			b.node.Tangle().ProcessMessage(message)
+		}
+	}
+	if votes > 1 {
+		b.mutex.Lock()
+		b.splitVotes++
+		b.mutex.Unlock()
+	}
+	return true
+}
+
+// conflictingColors returns the two colors with the highest approval weight in the view of the attacker.
+func (b *BalancingAttacker) conflictingColors() (leading, trailing multiverse.Color, conflicting bool) {
+	colors := make([]multiverse.Color, 0)
+	approvalWeights := b.node.Tangle().OpinionManager.ApprovalWeights()
+	for color := range approvalWeights {
+		if color != multiverse.UndefinedColor {
+			colors = append(colors, color)
+		}
+	}
+	if len(colors) < 2 {
+		return
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if approvalWeights[colors[i]] == approvalWeights[colors[j]] {
+			return colors[i] < colors[j]
+		}
+		return approvalWeights[colors[i]] > approvalWeights[colors[j]]
+	})
+	return colors[0], colors[1], true
+}
+
+// opinion returns the opinion of the node, read from its OpinionManager with BalancingOmniscient or estimated from its
+// last vote otherwise.
+func (b *BalancingAttacker) opinion(peerID network.PeerID) multiverse.Color {
+	if config.Params.BalancingOmniscient && observedNetwork != nil {
+		return observedNetwork.Peer(int(peerID)).Node.(multiverse.NodeInterface).Tangle().OpinionManager.Opinion()
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.lastVotes[peerID]
+}
+
+func (b *BalancingAttacker) messageBooked(messageID multiverse.MessageID) {
+	message := b.node.Tangle().Storage.Message(messageID)
+	messageMetadata := b.node.Tangle().Storage.MessageMetadata(messageID)
+	if message == nil || messageMetadata == nil || messageMetadata.InheritedColor() == multiverse.UndefinedColor {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.lastVotes[message.Issuer] = messageMetadata.InheritedColor()
+}
+
+// selectTips approves the tips of the color of the vote that is currently created.
+func (b *BalancingAttacker) selectTips(strongTips multiverse.MessageIDs, validation bool) multiverse.MessageIDs {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	if validation || b.voteColor == multiverse.UndefinedColor {
+		return strongTips
+	}
+	return b.node.Tangle().TipManager.TipSet(b.voteColor).StrongTips(config.Params.ParentsCount, multiverse.URTS{})
+}
+
+func (b *BalancingAttacker) gossipAllowed(neighbor network.PeerID, networkMessage interface{}) bool {
+	message, isMessage := networkMessage.(*multiverse.Message)
+	if !isMessage {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	receivers, isVote := b.voteReceivers[message.ID]
+	if !isVote {
+		return true
+	}
+	// the filter is asked once for every neighbor when the vote is gossiped, votes that are never gossiped are
+	// removed once they are pruned
+	if receivers.asked++; receivers.asked >= len(b.node.Peer().Neighbors) {
+		delete(b.voteReceivers, message.ID)
+	}
+	return receivers.receivers[neighbor]
+}
+
+// voteReceivers are the neighbors a vote is gossiped to and the number of neighbors the gossip filter was asked for.
+type voteReceivers struct {
+	receivers map[network.PeerID]bool
+	asked     int
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,92 @@
+package adversary
+
+import (
+	"sync"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region Censorship ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// censors are all nodes with the 'CensorTips' or the 'CensorScheduler' behavior.
+	censors      = make(map[*multiverse.Node]bool)
+	censorsMutex sync.RWMutex
+)
+
+// Censors returns all nodes with the 'CensorTips' or the 'CensorScheduler' behavior.
+func Censors() []*multiverse.Node {
+	censorsMutex.RLock()
+	defer censorsMutex.RUnlock()
+	censoringNodes := make([]*multiverse.Node, 0, len(censors))
+	for node := range censors {
+		censoringNodes = append(censoringNodes, node)
+	}
+	return censoringNodes
+}
+
+// IsCensored returns true if the messages of the issuer are censored.
+func IsCensored(issuer network.PeerID) bool {
+	for _, censoredIssuer := range config.Params.CensoredIssuers {
+		if network.PeerID(censoredIssuer) == issuer {
+			return true
+		}
+	}
+	return false
+}
+
+// CensorTips never approves the messages of the CensoredIssuers. Every censored tip is replaced by its closest
+// strong ancestors that are not censored.
+func CensorTips(node *multiverse.Node) {
+	registerCensor(node)
+	node.Tangle().Hooks.TipSelectors = append(node.Tangle().Hooks.TipSelectors, func(strongTips multiverse.MessageIDs, _ bool) multiverse.MessageIDs {
+		uncensoredTips := make(multiverse.MessageIDs)
+		visited := make(map[multiverse.MessageID]bool)
+		stack := make([]multiverse.MessageID, 0, len(strongTips))
+		for tip := range strongTips {
+			stack = append(stack, tip)
+		}
+		for len(stack) > 0 && len(uncensoredTips) < config.Params.ParentsCount {
+			tip := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[tip] {
+				continue
+			}
+			visited[tip] = true
+
+			message := node.Tangle().Storage.Message(tip)
+			if tip == multiverse.Genesis || message == nil || !IsCensored(message.Issuer) {
+				uncensoredTips.Add(tip)
+				continue
+			}
+			for strongParent := range message.StrongParents {
+				stack = append(stack, strongParent)
+			}
+		}
+		return uncensoredTips
+	})
+}
+
+// CensorScheduler never schedules the messages of the CensoredIssuers, so they are not gossiped, and does not answer
+// the requests for them.
+func CensorScheduler(node *multiverse.Node) {
+	registerCensor(node)
+	hooks := node.Tangle().Hooks
+	hooks.EnqueueFilters = append(hooks.EnqueueFilters, func(message *multiverse.Message) bool {
+		return !IsCensored(message.Issuer)
+	})
+	hooks.RequestHandlers = append(hooks.RequestHandlers, func(request *multiverse.MessageRequest) bool {
+		message := node.Tangle().Storage.Message(request.MessageID)
+		return message != nil && IsCensored(message.Issuer)
+	})
+}
+
+func registerCensor(node *multiverse.Node) {
+	censorsMutex.Lock()
+	defer censorsMutex.Unlock()
+	censors[node] = true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,110 @@
+package adversary
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto"
+	"github.com/iotaledger/hive.go/timedexecutor"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region EclipseAttacker //////////////////////////////////////////////////////////////////////////////////////////////
+
+// eclipseAttackers are all nodes with the 'Eclipse' behavior.
+var eclipseAttackers []*EclipseAttacker
+
+// EclipseAttackers returns all nodes with the 'Eclipse' behavior.
+func EclipseAttackers() []*EclipseAttacker {
+	return eclipseAttackers
+}
+
+// EclipseAttacker takes over the neighbor slots of the EclipseVictims, which is done by the network when the peers are
+// connected. It delays, reorders or drops the messages it gossips to the victims and the messages of the victims it
+// forwards to the rest of the network, depending on the EclipseMode.
+type EclipseAttacker struct {
+	node          *multiverse.Node
+	victims       map[network.PeerID]bool
+	timedExecutor *timedexecutor.TimedExecutor
+
+	delayedMessages int
+	droppedMessages int
+	ignoredRequests int
+
+	mutex sync.RWMutex
+}
+
+// NewEclipseAttacker adds the 'Eclipse' behavior to the node.
+func NewEclipseAttacker(node *multiverse.Node) {
+	e := &EclipseAttacker{
+		node:          node,
+		victims:       make(map[network.PeerID]bool),
+		timedExecutor: timedexecutor.New(1),
+	}
+	for _, victimID := range config.Params.EclipseVictims {
+		e.victims[network.PeerID(victimID)] = true
+	}
+	eclipseAttackers = append(eclipseAttackers, e)
+
+	hooks := node.Tangle().Hooks
+	hooks.GossipFilters = append(hooks.GossipFilters, e.gossipAllowed)
+	hooks.RequestHandlers = append(hooks.RequestHandlers, e.requestHandled)
+}
+
+// Node returns the node of the attacker.
+func (e *EclipseAttacker) Node() *multiverse.Node {
+	return e.node
+}
+
+// Stats returns the number of messages the attacker delayed and dropped and the number of requests of the victims it
+// ignored.
+func (e *EclipseAttacker) Stats() (delayedMessages, droppedMessages, ignoredRequests int) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.delayedMessages, e.droppedMessages, e.ignoredRequests
+}
+
+func (e *EclipseAttacker) gossipAllowed(neighbor network.PeerID, networkMessage interface{}) bool {
+	message, isMessage := networkMessage.(*multiverse.Message)
+	if !isMessage || (!e.victims[neighbor] && !e.victims[message.Issuer]) {
+		return true
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	switch config.Params.EclipseMode {
+	case "Drop":
+		if crypto.Randomness.Float64() < config.Params.EclipseDropProbability {
+			e.droppedMessages++
+			return false
+		}
+		return true
+	case "Delay", "Reorder":
+		delay := config.Params.EclipseDelay * time.Duration(config.Params.SlowdownFactor)
+		if config.Params.EclipseMode == "Reorder" {
+			delay = time.Duration(crypto.Randomness.Int63n(int64(delay) + 1))
+		}
+		connection := e.node.Peer().Neighbors[neighbor]
+		e.timedExecutor.ExecuteAfter(func() {
+			connection.Send(message)
+		}, delay)
+		e.delayedMessages++
+		return false
+	default:
+		return true
+	}
+}
+
+func (e *EclipseAttacker) requestHandled(request *multiverse.MessageRequest) bool {
+	if config.Params.EclipseAnswerRequests || !e.victims[request.Issuer] {
+		return false
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.ignoredRequests++
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,174 @@
+package adversary
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region Equivocator //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// equivocators are all nodes with the 'Equivocation' behavior.
+var equivocators []*Equivocator
+
+// Equivocators returns all nodes with the 'Equivocation' behavior.
+func Equivocators() []*Equivocator {
+	return equivocators
+}
+
+// Equivocator issues two conflicting validation blocks with the same sequence number instead of one and gossips each
+// of them to one half of its neighbors. Depending on the EquivocationMode the second block approves older parents or
+// the blocks approve the tips of different colors.
+type Equivocator struct {
+	node *multiverse.Node
+
+	// blockReceivers are the neighbors every equivocating block is gossiped to
+	blockReceivers    map[multiverse.MessageID]map[network.PeerID]bool
+	equivocations     int
+	firstEquivocation time.Time
+
+	mutex sync.RWMutex
+}
+
+// NewEquivocator adds the 'Equivocation' behavior to the node.
+func NewEquivocator(node *multiverse.Node) {
+	e := &Equivocator{
+		node:           node,
+		blockReceivers: make(map[multiverse.MessageID]map[network.PeerID]bool),
+	}
+	equivocators = append(equivocators, e)
+
+	hooks := node.Tangle().Hooks
+	hooks.ValidationHandlers = append(hooks.ValidationHandlers, e.issueEquivocation)
+	hooks.GossipFilters = append(hooks.GossipFilters, e.gossipAllowed)
+	node.Tangle().Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs multiverse.MessageIDs) {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+		for messageID := range messageIDs {
+			delete(e.blockReceivers, messageID)
+		}
+	}))
+}
+
+// Node returns the node of the equivocator.
+func (e *Equivocator) Node() *multiverse.Node {
+	return e.node
+}
+
+// Equivocations returns the number of pairs of conflicting validation blocks the equivocator issued and the time of
+// the first one.
+func (e *Equivocator) Equivocations() (equivocations int, firstEquivocation time.Time) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.equivocations, e.firstEquivocation
+}
+
+// issueEquivocation replaces the validation block by two conflicting blocks with the same sequence number.
+func (e *Equivocator) issueEquivocation() bool {
+	tangle := e.node.Tangle()
+	block, ok := tangle.MessageFactory.CreateMessage(true, multiverse.UndefinedColor)
+	if !ok {
+		return true
+	}
+	conflictingBlock := *block
+	conflictingBlock.ID = multiverse.NewMessageID()
+	e.conflictingParents(block, &conflictingBlock)
+
+	neighbors := make([]network.PeerID, 0, len(e.node.Peer().Neighbors))
+	for neighbor := range e.node.Peer().Neighbors {
+		neighbors = append(neighbors, neighbor)
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i] < neighbors[j] })
+	receivers := map[multiverse.MessageID]map[network.PeerID]bool{
+		block.ID:            make(map[network.PeerID]bool),
+		conflictingBlock.ID: make(map[network.PeerID]bool),
+	}
+	for i, neighbor := range neighbors {
+		if i%2 == 0 {
+			receivers[block.ID][neighbor] = true
+		} else {
+			receivers[conflictingBlock.ID][neighbor] = true
+		}
+	}
+
+	e.mutex.Lock()
+	e.blockReceivers[block.ID] = receivers[block.ID]
+	e.blockReceivers[conflictingBlock.ID] = receivers[conflictingBlock.ID]
+	if e.equivocations == 0 {
+		e.firstEquivocation = time.Now()
+	}
+	e.equivocations++
+	e.mutex.Unlock()
+
+	tangle.ProcessMessage(block)
+	tangle.ProcessMessage(&conflictingBlock)
+	return true
+}
+
+// conflictingParents makes the parents of the two blocks conflict as defined by the EquivocationMode.
+func (e *Equivocator) conflictingParents(block, conflictingBlock *multiverse.Message) {
+	tipManager := e.node.Tangle().TipManager
+	if config.Params.EquivocationMode == "Colors" {
+		if colors := e.conflictingColors(); len(colors) == 2 {
+			block.StrongParents = tipManager.TipSet(colors[0]).StrongTips(config.Params.ParentsCount, multiverse.URTS{})
+			conflictingBlock.StrongParents = tipManager.TipSet(colors[1]).StrongTips(config.Params.ParentsCount, multiverse.URTS{})
+			return
+		}
+	}
+
+	// the conflicting block approves the parents of the parents, so it skips the latest messages
+	olderParents := make(multiverse.MessageIDs)
+	for strongParent := range block.StrongParents {
+		parent := e.node.Tangle().Storage.Message(strongParent)
+		if parent == nil {
+			continue
+		}
+		for grandParent := range parent.StrongParents {
+			olderParents.Add(grandParent)
+		}
+	}
+	if len(olderParents) == 0 {
+		olderParents.Add(multiverse.Genesis)
+	}
+	conflictingBlock.StrongParents = olderParents
+	conflictingBlock.WeakParents = make(multiverse.MessageIDs)
+}
+
+// conflictingColors returns the two colors with the highest approval weight in the view of the equivocator.
+func (e *Equivocator) conflictingColors() []multiverse.Color {
+	approvalWeights := e.node.Tangle().OpinionManager.ApprovalWeights()
+	colors := make([]multiverse.Color, 0, len(approvalWeights))
+	for color := range approvalWeights {
+		if color != multiverse.UndefinedColor {
+			colors = append(colors, color)
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if approvalWeights[colors[i]] == approvalWeights[colors[j]] {
+			return colors[i] < colors[j]
+		}
+		return approvalWeights[colors[i]] > approvalWeights[colors[j]]
+	})
+	if len(colors) > 2 {
+		colors = colors[:2]
+	}
+	return colors
+}
+
+func (e *Equivocator) gossipAllowed(neighbor network.PeerID, networkMessage interface{}) bool {
+	message, isMessage := networkMessage.(*multiverse.Message)
+	if !isMessage {
+		return true
+	}
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	receivers, isEquivocation := e.blockReceivers[message.ID]
+	return !isEquivocation || receivers[neighbor]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,28 @@
+package adversary
+
+import (
+	"github.com/iotaledger/multivers-simulation/multiverse"
+)
+
+// region LazyTips /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LazyTips replaces the tips selected by the honest tip selection with one of their strong parents, so the messages of
+// the node approve older messages and do not help to confirm the recent ones.
+func LazyTips(node *multiverse.Node) {
+	node.Tangle().Hooks.TipSelectors = append(node.Tangle().Hooks.TipSelectors, func(strongTips multiverse.MessageIDs, _ bool) multiverse.MessageIDs {
+		lazyTips := make(multiverse.MessageIDs)
+		for tip := range strongTips {
+			lazyTip := tip
+			if message := node.Tangle().Storage.Message(tip); message != nil {
+				for strongParent := range message.StrongParents {
+					lazyTip = strongParent
+					break
+				}
+			}
+			lazyTips.Add(lazyTip)
+		}
+		return lazyTips
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
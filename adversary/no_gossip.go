@@ -1,41 +1,41 @@
 package adversary
 
 import (
-	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
 )
 
-// region NoGossipNode ///////////////////////////////////////////////////////////////////////////////////////////////////
+// region NoGossip /////////////////////////////////////////////////////////////////////////////////////////////////////
 
-type NoGossipNode struct {
-	*multiverse.Node
+// NoGossip withholds all messages, the own ones and the ones of other nodes, from the neighbors.
+func NoGossip(node *multiverse.Node) {
+	node.Tangle().Hooks.GossipFilters = append(node.Tangle().Hooks.GossipFilters, func(_ network.PeerID, networkMessage interface{}) bool {
+		_, isMessage := networkMessage.(*multiverse.Message)
+		return !isMessage
+	})
 }
 
-func NewNoGossipNode() interface{} {
-	node := multiverse.NewNode().(*multiverse.Node)
-	noGossipNode := &NoGossipNode{
-		node,
-	}
-	noGossipNode.UpdateGossipBehavior()
-	return noGossipNode
+// NoRequests does not answer the requests for missing messages of the neighbors.
+func NoRequests(node *multiverse.Node) {
+	node.Tangle().Hooks.RequestHandlers = append(node.Tangle().Hooks.RequestHandlers, func(*multiverse.MessageRequest) bool {
+		return true
+	})
 }
 
-func (n *NoGossipNode) UpdateGossipBehavior() {
-	n.Tangle().Booker.Events.MessageBooked.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
-		// do nothing - no gossiping
-	}))
-	n.Tangle().Requester.Events.Request.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
-		// do nothing - no answering requests for missing messages
-	}))
+// NoDoubleSpend does not issue colored messages, to not allow other nodes to count the opinion of the node for any of
+// the colors. Other adversary groups need to issue the double spends.
+func NoDoubleSpend(node *multiverse.Node) {
+	node.Tangle().Hooks.IssuanceHandlers = append(node.Tangle().Hooks.IssuanceHandlers, func(payload multiverse.Color) bool {
+		return payload != multiverse.UndefinedColor
+	})
 }
 
-func (n *NoGossipNode) AssignColor(color multiverse.Color) {
-	// do nothing - leave undefined color
+// NoIssuance does not issue any payloads, only the validation blocks of validators. It is the node of the legacy
+// NoGossip AdversaryType, which still gossips and answers requests.
+func NoIssuance(node *multiverse.Node) {
+	node.Tangle().Hooks.IssuanceHandlers = append(node.Tangle().Hooks.IssuanceHandlers, func(multiverse.Color) bool {
+		return true
+	})
 }
 
-func (n *NoGossipNode) IssuePayload(payload multiverse.Color) {
-	// do nothing - this node will not issue DS message, to not allow other nodes count his opinion for any of colors
-	// user needs to define other adv group that will issue DS
-}
-
-// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
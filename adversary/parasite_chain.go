@@ -0,0 +1,177 @@
+package adversary
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region ParasiteChain ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parasiteChains are the side-tangles of all nodes with the 'ParasiteChain' behavior.
+var parasiteChains []*ParasiteChain
+
+// ParasiteChains returns the side-tangles of all nodes with the 'ParasiteChain' behavior.
+func ParasiteChains() []*ParasiteChain {
+	return parasiteChains
+}
+
+// ParasiteChain privately builds a side-tangle once the double spend color is assigned to the node. The side-tangle
+// starts from a message that is ParasiteRootAge old and every own message approves the previous one. The messages are
+// withheld from the neighbors until they are released after ParasiteReleaseDelay, afterwards the chain is extended in
+// public.
+type ParasiteChain struct {
+	Events *ParasiteChainEvents
+
+	node *multiverse.Node
+	root multiverse.MessageID
+	// lastMessage is the tip of the side-tangle
+	lastMessage multiverse.MessageID
+	messages    map[multiverse.MessageID]bool
+	withheld    []*multiverse.Message
+
+	startRequested bool
+	startTime      time.Time
+	releaseTime    time.Time
+
+	mutex sync.RWMutex
+}
+
+// ParasiteChainEvents contains the events of a ParasiteChain.
+type ParasiteChainEvents struct {
+	// Released is triggered with the number of withheld messages when the side-tangle is released.
+	Released *events.Event
+}
+
+// NewParasiteChain adds the 'ParasiteChain' behavior to the node.
+func NewParasiteChain(node *multiverse.Node) {
+	p := &ParasiteChain{
+		Events: &ParasiteChainEvents{
+			Released: events.NewEvent(releasedEventCaller),
+		},
+		node:     node,
+		messages: make(map[multiverse.MessageID]bool),
+	}
+	parasiteChains = append(parasiteChains, p)
+
+	hooks := node.Tangle().Hooks
+	hooks.ColorAssigned = append(hooks.ColorAssigned, func(multiverse.Color) {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		p.startRequested = true
+	})
+	hooks.IssuanceHandlers = append(hooks.IssuanceHandlers, func(multiverse.Color) bool {
+		p.update()
+		return false
+	})
+	hooks.TipSelectors = append(hooks.TipSelectors, p.selectTips)
+	hooks.GossipFilters = append(hooks.GossipFilters, p.gossipAllowed)
+	node.Tangle().Storage.Events.MessageStored.Attach(events.NewClosure(p.messageStored))
+}
+
+// Node returns the node that builds the side-tangle.
+func (p *ParasiteChain) Node() *multiverse.Node {
+	return p.node
+}
+
+// Contains returns true if the message belongs to the side-tangle.
+func (p *ParasiteChain) Contains(messageID multiverse.MessageID) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.messages[messageID]
+}
+
+// Stats returns the root of the side-tangle, the number of its messages and the start and release times, which are
+// zero if the side-tangle was not started or released yet.
+func (p *ParasiteChain) Stats() (root multiverse.MessageID, messages int, startTime, releaseTime time.Time) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.root, len(p.messages), p.startTime, p.releaseTime
+}
+
+// update starts the side-tangle once the color was assigned and releases it after ParasiteReleaseDelay. It is called
+// before every own message.
+func (p *ParasiteChain) update() {
+	p.mutex.Lock()
+	if p.startRequested && p.startTime.IsZero() {
+		p.startTime = time.Now()
+		p.root = p.findRoot()
+		p.lastMessage = p.root
+	}
+	if p.startTime.IsZero() || !p.releaseTime.IsZero() || time.Since(p.startTime) < config.Params.ParasiteReleaseDelay*time.Duration(config.Params.SlowdownFactor) {
+		p.mutex.Unlock()
+		return
+	}
+	p.releaseTime = time.Now()
+	withheld := p.withheld
+	p.withheld = nil
+	p.mutex.Unlock()
+
+	for _, message := range withheld {
+		p.node.Peer().GossipNetworkMessage(message)
+	}
+	p.Events.Released.Trigger(len(withheld))
+}
+
+// findRoot walks from a tip to the first message that is at least ParasiteRootAge old.
+func (p *ParasiteChain) findRoot() (root multiverse.MessageID) {
+	rootTime := time.Now().Add(-config.Params.ParasiteRootAge * time.Duration(config.Params.SlowdownFactor))
+	for root = range p.node.Tangle().TipManager.TipSet(multiverse.UndefinedColor).StrongTips(1, multiverse.URTS{}) {
+		break
+	}
+	for {
+		message := p.node.Tangle().Storage.Message(root)
+		if message == nil || message.IssuanceTime.Before(rootTime) {
+			return
+		}
+		for strongParent := range message.StrongParents {
+			root = strongParent
+			break
+		}
+	}
+}
+
+func (p *ParasiteChain) selectTips(strongTips multiverse.MessageIDs, _ bool) multiverse.MessageIDs {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.startTime.IsZero() {
+		return strongTips
+	}
+	return multiverse.NewMessageIDs(p.lastMessage)
+}
+
+func (p *ParasiteChain) messageStored(messageID multiverse.MessageID, message *multiverse.Message, _ *multiverse.MessageMetadata) {
+	if message.Issuer != p.node.Peer().ID {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, extendsChain := message.StrongParents[p.lastMessage]; p.startTime.IsZero() || !extendsChain {
+		return
+	}
+	p.lastMessage = messageID
+	p.messages[messageID] = true
+	if p.releaseTime.IsZero() {
+		p.withheld = append(p.withheld, message)
+	}
+}
+
+func (p *ParasiteChain) gossipAllowed(_ network.PeerID, networkMessage interface{}) bool {
+	message, isMessage := networkMessage.(*multiverse.Message)
+	if !isMessage {
+		return true
+	}
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return !p.messages[message.ID] || !p.releaseTime.IsZero()
+}
+
+func releasedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(int))(params[0].(int))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,30 +5,12 @@ import (
 	"github.com/iotaledger/multivers-simulation/multiverse"
 )
 
-// region ShiftingOpinionNode ///////////////////////////////////////////////////////////////////////////////////////////////////
+// region SameOpinion //////////////////////////////////////////////////////////////////////////////////////////////////
 
-type SameOpinionNode struct {
-	*multiverse.Node
-}
-
-func NewSameOpinionNode() interface{} {
-	node := multiverse.NewNode().(*multiverse.Node)
-	shiftingNode := &SameOpinionNode{
-		node,
-	}
-	shiftingNode.setupOpinionManager()
-	return shiftingNode
-
-}
-
-func (s *SameOpinionNode) setupOpinionManager() {
-	om := s.Tangle().OpinionManager
-	s.Tangle().OpinionManager = NewShiftingOpinionManager(om)
-	s.Tangle().OpinionManager.Setup()
-}
-
-func (s *SameOpinionNode) AssignColor(color multiverse.Color) {
-	s.Tangle().OpinionManager.SetOpinion(color)
+// SameOpinion makes the node keep the double spend color assigned to it regardless of the approval weights.
+func SameOpinion(node *multiverse.Node) {
+	node.Tangle().OpinionManager = NewSameOpinionManager(node.Tangle().OpinionManager)
+	node.Tangle().Hooks.ColorAssigned = append(node.Tangle().Hooks.ColorAssigned, node.Tangle().OpinionManager.SetOpinion)
 }
 
 type SameOpinionManager struct {
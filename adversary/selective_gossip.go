@@ -0,0 +1,40 @@
+package adversary
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region SelectiveGossip //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SelectiveGossip gossips the messages only to a random subset of AdversaryGossipFraction of the neighbors, the
+// requests for missing messages are still sent to all neighbors.
+func SelectiveGossip(node *multiverse.Node) {
+	var selectOnce sync.Once
+	selectedNeighbors := make(map[network.PeerID]bool)
+
+	node.Tangle().Hooks.GossipFilters = append(node.Tangle().Hooks.GossipFilters, func(neighbor network.PeerID, networkMessage interface{}) bool {
+		if _, isMessage := networkMessage.(*multiverse.Message); !isMessage {
+			return true
+		}
+		// the neighbors are only known once the peers are connected
+		selectOnce.Do(func() {
+			neighbors := make([]network.PeerID, 0, len(node.Peer().Neighbors))
+			for neighborID := range node.Peer().Neighbors {
+				neighbors = append(neighbors, neighborID)
+			}
+			selectedCount := int(math.Round(config.Params.AdversaryGossipFraction * float64(len(neighbors))))
+			for _, i := range rand.Perm(len(neighbors))[:selectedCount] {
+				selectedNeighbors[neighbors[i]] = true
+			}
+		})
+		return selectedNeighbors[neighbor]
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,30 +5,13 @@ import (
 	"github.com/iotaledger/multivers-simulation/multiverse"
 )
 
-// region ShiftingOpinionNode ///////////////////////////////////////////////////////////////////////////////////////////////////
+// region ShiftingOpinion //////////////////////////////////////////////////////////////////////////////////////////////
 
-type ShiftingOpinionNode struct {
-	*multiverse.Node
-}
-
-func NewShiftingOpinionNode() interface{} {
-	node := multiverse.NewNode().(*multiverse.Node)
-	shiftingNode := &ShiftingOpinionNode{
-		node,
-	}
-	shiftingNode.setupOpinionManager()
-	return shiftingNode
-
-}
-
-func (s *ShiftingOpinionNode) setupOpinionManager() {
-	om := s.Tangle().OpinionManager
-	s.Tangle().OpinionManager = NewShiftingOpinionManager(om)
-	s.Tangle().OpinionManager.Setup()
-}
-
-func (s *ShiftingOpinionNode) AssignColor(color multiverse.Color) {
-	s.Tangle().OpinionManager.SetOpinion(color)
+// ShiftingOpinion makes the node always like the color with the second highest approval weight and vote for the double
+// spend color assigned to it.
+func ShiftingOpinion(node *multiverse.Node) {
+	node.Tangle().OpinionManager = NewShiftingOpinionManager(node.Tangle().OpinionManager)
+	node.Tangle().Hooks.ColorAssigned = append(node.Tangle().Hooks.ColorAssigned, node.Tangle().OpinionManager.SetOpinion)
 }
 
 type ShiftingOpinionManager struct {
@@ -0,0 +1,138 @@
+package adversary
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+)
+
+// region Spammer //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// spammers are all nodes with the 'Spam' behavior.
+var spammers []*Spammer
+
+// Spammers returns all nodes with the 'Spam' behavior.
+func Spammers() []*Spammer {
+	return spammers
+}
+
+// Spammer issues data messages at SpamRate on top of its own issuing rate, without asking its RateSetter. Depending on
+// the SpamMode it spams all the time, in bursts or only in the SpamSlots. The spam burns the mana defined by SpamBurn
+// and approves the parents defined by SpamParents.
+type Spammer struct {
+	node *multiverse.Node
+	// lastSpam is the last spam message, which the next one approves with the 'Unready' SpamParents
+	lastSpam  multiverse.MessageID
+	spamSlots map[multiverse.SlotIndex]bool
+	issued    int
+
+	mutex sync.RWMutex
+}
+
+// NewSpammer adds the 'Spam' behavior to the node.
+func NewSpammer(node *multiverse.Node) {
+	s := &Spammer{
+		node:      node,
+		lastSpam:  multiverse.Genesis,
+		spamSlots: make(map[multiverse.SlotIndex]bool),
+	}
+	for _, slot := range config.Params.SpamSlots {
+		s.spamSlots[multiverse.SlotIndex(slot)] = true
+	}
+	spammers = append(spammers, s)
+
+	node.Tangle().Hooks.IssuanceHandlers = append(node.Tangle().Hooks.IssuanceHandlers, s.issueSpam)
+}
+
+// Node returns the node of the spammer.
+func (s *Spammer) Node() *multiverse.Node {
+	return s.node
+}
+
+// Issued returns the number of spam messages issued by the spammer.
+func (s *Spammer) Issued() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.issued
+}
+
+// Spam issues a data message at every tick of SpamRate in which the SpamMode is active, until the node stops issuing.
+func (s *Spammer) Spam(simulationStartTime time.Time) {
+	if config.Params.SpamRate <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(float64(time.Second) * float64(config.Params.SlowdownFactor) / config.Params.SpamRate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.node.Peer().ShutdownIssuing:
+			return
+		case now := <-ticker.C:
+			if s.active(now, simulationStartTime) {
+				s.node.IssuePayload(multiverse.UndefinedColor)
+			}
+		}
+	}
+}
+
+// active returns true if the SpamMode spams at the given time.
+func (s *Spammer) active(now, simulationStartTime time.Time) bool {
+	switch config.Params.SpamMode {
+	case "Bursty":
+		burstPeriod := config.Params.SpamBurstPeriod * time.Duration(config.Params.SlowdownFactor)
+		burstLength := config.Params.SpamBurstLength * time.Duration(config.Params.SlowdownFactor)
+		return burstPeriod > 0 && now.Sub(simulationStartTime)%burstPeriod < burstLength
+	case "Slot":
+		return s.spamSlots[s.node.Tangle().Storage.SlotIndex(now)]
+	default:
+		return true
+	}
+}
+
+// issueSpam replaces the data messages of the node by spam, colored messages are issued as usual.
+func (s *Spammer) issueSpam(payload multiverse.Color) bool {
+	if payload != multiverse.UndefinedColor {
+		return false
+	}
+	tangle := s.node.Tangle()
+
+	var message *multiverse.Message
+	switch config.Params.SpamBurn {
+	case "Zero":
+		message = tangle.MessageFactory.CreateMessageWithBurn(false, payload, 0)
+	case "Minimal":
+		burn := tangle.Storage.RMC(tangle.Storage.SlotIndex(time.Now()))
+		if tangle.ManaManager.GetNodeAccessMana(tangle.Peer.ID) >= burn {
+			tangle.ManaManager.DecreaseNodeAccessMana(tangle.Peer.ID, burn)
+		}
+		message = tangle.MessageFactory.CreateMessageWithBurn(false, payload, burn)
+	default:
+		var ok bool
+		if message, ok = tangle.MessageFactory.CreateMessage(false, payload); !ok {
+			return true
+		}
+	}
+
+	s.mutex.Lock()
+	switch config.Params.SpamParents {
+	case "Unsolid":
+		// the parent is never issued, so the message never becomes solid
+		message.StrongParents.Add(multiverse.NewMessageID())
+	case "Unready":
+		// the previous spam is still waiting in the schedulers, so the message is not ready
+		message.StrongParents = multiverse.NewMessageIDs(s.lastSpam)
+	}
+	s.lastSpam = message.ID
+	s.issued++
+	s.mutex.Unlock()
+
+	tangle.ProcessMessage(message)
+	// the spam does not wait for the own scheduler
+	s.node.Peer().GossipNetworkMessage(message)
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,24 @@
+package adversary
+
+import (
+	"github.com/iotaledger/multivers-simulation/multiverse"
+)
+
+// region Sybil ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sybils are all nodes with the 'Sybil' behavior.
+var sybils []*multiverse.Node
+
+// Sybils returns all nodes with the 'Sybil' behavior.
+func Sybils() []*multiverse.Node {
+	return sybils
+}
+
+// Sybil marks the node as one of the cheap identities of a Sybil attacker. Every identity takes SybilConnections
+// neighbor slots of honest nodes and gets its own share of the BandwidthDistribution, combined with 'Speedup' it
+// issues at its full bandwidth.
+func Sybil(node *multiverse.Node) {
+	sybils = append(sybils, node)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
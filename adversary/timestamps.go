@@ -0,0 +1,46 @@
+package adversary
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+)
+
+// region Timestamps ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// timestampManipulators are all nodes with the 'Timestamps' behavior.
+var timestampManipulators []*multiverse.Node
+
+// TimestampManipulators returns all nodes with the 'Timestamps' behavior.
+func TimestampManipulators() []*multiverse.Node {
+	return timestampManipulators
+}
+
+// Timestamps shifts the IssuanceTime of every own message by a random duration between TimestampShiftMin and
+// TimestampShiftMax, so the messages are back-dated or future-dated.
+func Timestamps(node *multiverse.Node) {
+	timestampManipulators = append(timestampManipulators, node)
+	node.Tangle().Hooks.IssuanceHandlers = append(node.Tangle().Hooks.IssuanceHandlers, func(payload multiverse.Color) bool {
+		message, ok := node.Tangle().MessageFactory.CreateMessage(false, payload)
+		if !ok {
+			return true
+		}
+		message.IssuanceTime = message.IssuanceTime.Add(timestampShift())
+		node.Tangle().ProcessMessage(message)
+		return true
+	})
+}
+
+// timestampShift returns a random shift between TimestampShiftMin and TimestampShiftMax.
+func timestampShift() time.Duration {
+	shiftMin := config.Params.TimestampShiftMin * time.Duration(config.Params.SlowdownFactor)
+	shiftMax := config.Params.TimestampShiftMax * time.Duration(config.Params.SlowdownFactor)
+	if shiftMax <= shiftMin {
+		return shiftMin
+	}
+	return shiftMin + time.Duration(crypto.Randomness.Int63n(int64(shiftMax-shiftMin)+1))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
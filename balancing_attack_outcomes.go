@@ -0,0 +1,112 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region balancing attack /////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	balancingOutcome      = &balancingAttackOutcome{}
+	balancingOutcomeMutex sync.Mutex
+)
+
+// balancingAttackOutcome tracks how often the honest nodes change their opinion while the 'Balancing' attackers try to
+// keep them split and when the honest nodes reach consensus.
+type balancingAttackOutcome struct {
+	honestOpinionChanges int64
+	consensusTime        time.Time
+}
+
+// monitorBalancingAttack counts the opinion changes of the honest nodes if there are balancing attackers.
+func monitorBalancingAttack(net *network.Network) {
+	if len(adversary.BalancingAttackers()) == 0 {
+		return
+	}
+	adversary.ObserveNetwork(net)
+
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		peer.Node.(multiverse.NodeInterface).Tangle().OpinionManager.Events().OpinionChanged.Attach(events.NewClosure(func(oldOpinion multiverse.Color, newOpinion multiverse.Color, weight int64) {
+			balancingOutcomeMutex.Lock()
+			defer balancingOutcomeMutex.Unlock()
+			balancingOutcome.honestOpinionChanges++
+		}))
+	}
+}
+
+// recordBalancingConsensus records the time the honest nodes reached consensus.
+func recordBalancingConsensus() {
+	balancingOutcomeMutex.Lock()
+	defer balancingOutcomeMutex.Unlock()
+	if balancingOutcome.consensusTime.IsZero() {
+		balancingOutcome.consensusTime = time.Now()
+	}
+}
+
+// dumpBalancingAttack writes the votes of every balancing attacker next to the weight of all adversaries, the flips of
+// the honest nodes and the time they needed to reach consensus after the double spend.
+func dumpBalancingAttack(net *network.Network) {
+	balancingAttackers := adversary.BalancingAttackers()
+	if len(balancingAttackers) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Weight Share",
+		"Adversary Weight Share",
+		"Omniscient",
+		"Votes",
+		"Split Votes",
+		"Honest Flips",
+		"Honest Opinion Changes",
+		"Time To Consensus Since Double Spend (ns)",
+	}
+	rows := make(csvRows, 0)
+
+	totalWeight := float64(net.WeightDistribution.TotalWeight())
+	adversaryWeight := uint64(0)
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			adversaryWeight += net.WeightDistribution.Weight(peer.ID)
+		}
+	}
+
+	balancingOutcomeMutex.Lock()
+	defer balancingOutcomeMutex.Unlock()
+	timeToConsensus := int64(-1)
+	if !balancingOutcome.consensusTime.IsZero() && !dsIssuanceTime.IsZero() {
+		timeToConsensus = balancingOutcome.consensusTime.Sub(dsIssuanceTime).Nanoseconds()
+	}
+	for _, balancingAttacker := range balancingAttackers {
+		peerID := balancingAttacker.Node().Peer().ID
+		votes, splitVotes := balancingAttacker.Votes()
+		record := []string{
+			strconv.FormatInt(int64(peerID), 10),
+			strconv.FormatFloat(float64(net.WeightDistribution.Weight(peerID))/totalWeight, 'f', 6, 64),
+			strconv.FormatFloat(float64(adversaryWeight)/totalWeight, 'f', 6, 64),
+			strconv.FormatBool(config.Params.BalancingOmniscient),
+			strconv.Itoa(votes),
+			strconv.Itoa(splitVotes),
+			strconv.FormatInt(atomicCounters.Get("honestFlips"), 10),
+			strconv.FormatInt(balancingOutcome.honestOpinionChanges, 10),
+			strconv.FormatInt(timeToConsensus, 10),
+		}
+		rows = append(rows, record)
+	}
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "balancingAttack.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"math"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+	"github.com/iotaledger/multivers-simulation/singlenodeattacks"
+)
+
+// region blowball /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	blowballOutcome      = &blowballAttackOutcome{firstConfirmations: make(map[multiverse.MessageID]time.Time)}
+	blowballOutcomeMutex sync.Mutex
+)
+
+// blowballAttackOutcome tracks the mean tip pool size of the honest nodes over time and the first confirmation of every
+// message at the honest nodes.
+type blowballAttackOutcome struct {
+	tipPoolSamples []tipPoolSample
+	// firstConfirmations are the times the messages were first confirmed by an honest node
+	firstConfirmations map[multiverse.MessageID]time.Time
+	// honestConfirmations are the messages of the honest nodes first confirmed by an honest node
+	honestConfirmations []honestConfirmation
+}
+
+type tipPoolSample struct {
+	time        time.Time
+	meanTipPool float64
+}
+
+type honestConfirmation struct {
+	issuanceTime     time.Time
+	confirmationTime time.Duration
+}
+
+func isBlowballNode(peerID network.PeerID) bool {
+	for _, blowballNode := range singlenodeattacks.BlowballNodes() {
+		if blowballNode.Peer().ID == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// monitorBlowball samples the mean tip pool size of the honest nodes at every ConsensusMonitorTick and records the
+// first confirmation of every message at the honest nodes, if there are blowball nodes.
+func monitorBlowball(net *network.Network) {
+	if len(singlenodeattacks.BlowballNodes()) == 0 {
+		return
+	}
+
+	honestTangles := make([]*multiverse.Tangle, 0, len(net.Peers))
+	for _, peer := range net.Peers {
+		if isBlowballNode(peer.ID) || network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		honestTangles = append(honestTangles, tangle)
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			if message.Validation {
+				return
+			}
+			blowballIssuer := isBlowballNode(message.Issuer)
+			if !blowballIssuer && network.IsAdversary(int(message.Issuer)) {
+				return
+			}
+			blowballOutcomeMutex.Lock()
+			defer blowballOutcomeMutex.Unlock()
+			if _, confirmed := blowballOutcome.firstConfirmations[message.ID]; confirmed {
+				return
+			}
+			blowballOutcome.firstConfirmations[message.ID] = messageMetadata.ConfirmationTime()
+			if blowballIssuer {
+				return
+			}
+			blowballOutcome.honestConfirmations = append(blowballOutcome.honestConfirmations, honestConfirmation{
+				issuanceTime:     message.IssuanceTime,
+				confirmationTime: messageMetadata.ConfirmationTime().Sub(message.IssuanceTime),
+			})
+		}))
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(config.Params.SlowdownFactor*config.Params.ConsensusMonitorTick) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				tipPool := 0
+				for _, tangle := range honestTangles {
+					tipPool += tangle.TipManager.TipSet(multiverse.UndefinedColor).Size()
+				}
+				blowballOutcomeMutex.Lock()
+				blowballOutcome.tipPoolSamples = append(blowballOutcome.tipPoolSamples, tipPoolSample{
+					time:        now,
+					meanTipPool: float64(tipPool) / math.Max(float64(len(honestTangles)), 1),
+				})
+				blowballOutcomeMutex.Unlock()
+			case <-shutdownGlobalMetrics:
+				return
+			}
+		}
+	}()
+}
+
+// dumpBlowball writes a row for every blowball with the mean tip pool size of the honest nodes before it and its peak
+// within BlowballDelay after it, how long the confirmation of its messages took, and the mean confirmation time of the
+// honest messages issued within BlowballDelay before and after it.
+func dumpBlowball() {
+	blowballNodes := singlenodeattacks.BlowballNodes()
+	if len(blowballNodes) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Blowball",
+		"Blowball Rules",
+		"ns since start",
+		"Target Age (ns)",
+		"Messages",
+		"Mean Honest Tip Pool Before",
+		"Max Mean Honest Tip Pool After",
+		"Tip Pool Inflation",
+		"Confirmed Messages",
+		"Confirmation Delay (ns)",
+		"Honest Confirmation Time Before (ns)",
+		"Honest Confirmation Time After (ns)",
+	}
+	rows := make(csvRows, 0)
+
+	window := time.Duration(config.Params.SlowdownFactor*config.Params.BlowballDelay) * time.Second
+	// meanHonestConfirmationTime returns the mean confirmation time of the honest messages issued in [start, end), -1
+	// if none was confirmed
+	meanHonestConfirmationTime := func(start, end time.Time) int64 {
+		confirmed, total := int64(0), time.Duration(0)
+		for _, confirmation := range blowballOutcome.honestConfirmations {
+			if !confirmation.issuanceTime.Before(start) && confirmation.issuanceTime.Before(end) {
+				confirmed++
+				total += confirmation.confirmationTime
+			}
+		}
+		if confirmed == 0 {
+			return -1
+		}
+		return total.Nanoseconds() / confirmed
+	}
+
+	blowballOutcomeMutex.Lock()
+	defer blowballOutcomeMutex.Unlock()
+	for _, blowballNode := range blowballNodes {
+		for i, blowball := range blowballNode.Blowballs() {
+			tipPoolBefore, maxTipPoolAfter := 0.0, 0.0
+			for _, sample := range blowballOutcome.tipPoolSamples {
+				if !sample.time.After(blowball.IssuanceTime) {
+					tipPoolBefore = sample.meanTipPool
+				} else if !sample.time.After(blowball.IssuanceTime.Add(window)) && sample.meanTipPool > maxTipPoolAfter {
+					maxTipPoolAfter = sample.meanTipPool
+				}
+			}
+
+			// the delay stays -1 if none of the messages of the blowball was confirmed
+			confirmed, confirmationDelay := 0, time.Duration(-1)
+			for messageID := range blowball.Messages {
+				if confirmationTime, exists := blowballOutcome.firstConfirmations[messageID]; exists {
+					confirmed++
+					if delay := confirmationTime.Sub(blowball.IssuanceTime); delay > confirmationDelay {
+						confirmationDelay = delay
+					}
+				}
+			}
+
+			record := []string{
+				strconv.FormatInt(int64(blowballNode.Peer().ID), 10),
+				strconv.Itoa(i),
+				config.Params.BlowballRules,
+				strconv.FormatInt(blowball.IssuanceTime.Sub(simulationStartTime).Nanoseconds(), 10),
+				strconv.FormatInt(blowball.TargetAge.Nanoseconds(), 10),
+				strconv.Itoa(len(blowball.Messages)),
+				strconv.FormatFloat(tipPoolBefore, 'f', 6, 64),
+				strconv.FormatFloat(maxTipPoolAfter, 'f', 6, 64),
+				strconv.FormatFloat(math.Max(maxTipPoolAfter-tipPoolBefore, 0), 'f', 6, 64),
+				strconv.Itoa(confirmed),
+				strconv.FormatInt(confirmationDelay.Nanoseconds(), 10),
+				strconv.FormatInt(meanHonestConfirmationTime(blowball.IssuanceTime.Add(-window), blowball.IssuanceTime), 10),
+				strconv.FormatInt(meanHonestConfirmationTime(blowball.IssuanceTime, blowball.IssuanceTime.Add(window)), 10),
+			}
+			rows = append(rows, record)
+		}
+	}
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "blowball.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
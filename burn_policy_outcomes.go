@@ -0,0 +1,147 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region burn policy outcomes /////////////////////////////////////////////////////////////////////////////////////////
+
+// droppedMessagesWindow is the number of slots for which the dropped messages are remembered, a message that is still
+// in the buffer of a node after it is dropped that long might be counted twice.
+const droppedMessagesWindow = 30
+
+var (
+	burnPolicyOutcomes = make(map[multiverse.BurnPolicyType]*burnPolicyOutcome)
+	// droppedMessages holds the dropped messages per slot of their issuance, for the last droppedMessagesWindow slots
+	droppedMessages        = make(map[multiverse.SlotIndex]multiverse.MessageIDs)
+	burnPolicyOutcomeMutex sync.Mutex
+)
+
+// burnPolicyOutcome accumulates the outcome of the data messages issued by the nodes with the same burn policy.
+type burnPolicyOutcome struct {
+	issued    int64
+	confirmed int64
+	dropped   int64
+	latency   time.Duration
+	burn      float64
+}
+
+func burnPolicyOutcomeOf(issuer network.PeerID) *burnPolicyOutcome {
+	policy := multiverse.BurnPolicyType(config.Params.BurnPolicies[issuer])
+	outcome, exists := burnPolicyOutcomes[policy]
+	if !exists {
+		outcome = &burnPolicyOutcome{}
+		burnPolicyOutcomes[policy] = outcome
+	}
+	return outcome
+}
+
+func recordBurnPolicyIssuance(message *multiverse.Message) {
+	if message.Validation {
+		return
+	}
+	burnPolicyOutcomeMutex.Lock()
+	defer burnPolicyOutcomeMutex.Unlock()
+	outcome := burnPolicyOutcomeOf(message.Issuer)
+	outcome.issued++
+	outcome.burn += message.ManaBurnValue
+}
+
+func recordBurnPolicyConfirmation(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+	if message.Validation {
+		return
+	}
+	burnPolicyOutcomeMutex.Lock()
+	defer burnPolicyOutcomeMutex.Unlock()
+	outcome := burnPolicyOutcomeOf(message.Issuer)
+	outcome.confirmed++
+	outcome.latency += messageMetadata.ConfirmationTime().Sub(message.IssuanceTime)
+}
+
+// recordBurnPolicyDrop counts the message as dropped the first time any node drops it.
+func recordBurnPolicyDrop(message *multiverse.Message) {
+	if message.Validation {
+		return
+	}
+	burnPolicyOutcomeMutex.Lock()
+	defer burnPolicyOutcomeMutex.Unlock()
+	currentSlot := simulationSlotIndex(time.Now())
+	for slot := range droppedMessages {
+		if slot+droppedMessagesWindow < currentSlot {
+			delete(droppedMessages, slot)
+		}
+	}
+
+	slot := simulationSlotIndex(message.IssuanceTime)
+	if _, dropped := droppedMessages[slot][message.ID]; dropped {
+		return
+	}
+	if droppedMessages[slot] == nil {
+		droppedMessages[slot] = multiverse.NewMessageIDs()
+	}
+	droppedMessages[slot].Add(message.ID)
+	burnPolicyOutcomeOf(message.Issuer).dropped++
+}
+
+// simulationSlotIndex returns the slot of the given time, counted from the start of the simulation.
+func simulationSlotIndex(t time.Time) multiverse.SlotIndex {
+	slotDuration := config.Params.SlotTime * time.Duration(config.Params.SlowdownFactor)
+	return multiverse.SlotIndex(t.Sub(simulationStartTime) / slotDuration)
+}
+
+// dumpBurnPolicyOutcomes writes the confirmation latency, the burnt mana and the drop rate of the data messages per
+// burn policy.
+func dumpBurnPolicyOutcomes() {
+	header := []string{
+		"Burn Policy",
+		"Issued Messages",
+		"Confirmed Messages",
+		"Dropped Messages",
+		"Drop Rate",
+		"Mean Confirmation Latency (ns)",
+		"Total Burn",
+		"Mean Burn",
+	}
+	rows := make(csvRows, 0)
+
+	burnPolicyOutcomeMutex.Lock()
+	defer burnPolicyOutcomeMutex.Unlock()
+	policies := make([]int, 0, len(burnPolicyOutcomes))
+	for policy := range burnPolicyOutcomes {
+		policies = append(policies, int(policy))
+	}
+	sort.Ints(policies)
+	for _, policy := range policies {
+		outcome := burnPolicyOutcomes[multiverse.BurnPolicyType(policy)]
+		dropRate, meanBurn, meanLatency := 0.0, 0.0, int64(0)
+		if outcome.issued > 0 {
+			dropRate = float64(outcome.dropped) / float64(outcome.issued)
+			meanBurn = outcome.burn / float64(outcome.issued)
+		}
+		if outcome.confirmed > 0 {
+			meanLatency = outcome.latency.Nanoseconds() / outcome.confirmed
+		}
+		record := []string{
+			multiverse.BurnPolicyType(policy).String(),
+			strconv.FormatInt(outcome.issued, 10),
+			strconv.FormatInt(outcome.confirmed, 10),
+			strconv.FormatInt(outcome.dropped, 10),
+			strconv.FormatFloat(dropRate, 'f', 6, 64),
+			strconv.FormatInt(meanLatency, 10),
+			strconv.FormatFloat(outcome.burn, 'f', 6, 64),
+			strconv.FormatFloat(meanBurn, 'f', 6, 64),
+		}
+		rows = append(rows, record)
+	}
+	writeCSV(path.Join(config.Params.SchedulerOutputDir, "burnPolicyOutcomes.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region censorship ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	censorshipOutcomes     = make(map[network.PeerID]*censorshipOutcome)
+	decidedByHonestNodes   = make(map[multiverse.MessageID]bool)
+	censorshipOutcomeMutex sync.Mutex
+)
+
+// censorshipOutcome tracks the messages of an issuer until the first honest node confirms or orphans them.
+type censorshipOutcome struct {
+	issued              int64
+	confirmed           int64
+	orphaned            int64
+	confirmationTime    time.Duration
+	maxConfirmationTime time.Duration
+}
+
+func (c *censorshipOutcome) add(other *censorshipOutcome) {
+	c.issued += other.issued
+	c.confirmed += other.confirmed
+	c.orphaned += other.orphaned
+	c.confirmationTime += other.confirmationTime
+	if other.maxConfirmationTime > c.maxConfirmationTime {
+		c.maxConfirmationTime = other.maxConfirmationTime
+	}
+}
+
+// monitorCensorship records for the messages of every honest issuer when they are first confirmed or orphaned by an
+// honest node, if there are censors.
+func monitorCensorship(net *network.Network) {
+	if len(adversary.Censors()) == 0 {
+		return
+	}
+
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		censorshipOutcomes[peer.ID] = &censorshipOutcome{}
+	}
+
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		peerID := peer.ID
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID multiverse.MessageID, message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+			if message.Issuer != peerID {
+				return
+			}
+			censorshipOutcomeMutex.Lock()
+			defer censorshipOutcomeMutex.Unlock()
+			censorshipOutcomes[peerID].issued++
+		}))
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			censorshipOutcomeMutex.Lock()
+			defer censorshipOutcomeMutex.Unlock()
+			issuerOutcome, honestIssuer := censorshipOutcomes[message.Issuer]
+			if !honestIssuer || decidedByHonestNodes[message.ID] {
+				return
+			}
+			decidedByHonestNodes[message.ID] = true
+			confirmationTime := messageMetadata.ConfirmationTime().Sub(message.IssuanceTime)
+			issuerOutcome.confirmed++
+			issuerOutcome.confirmationTime += confirmationTime
+			if confirmationTime > issuerOutcome.maxConfirmationTime {
+				issuerOutcome.maxConfirmationTime = confirmationTime
+			}
+		}))
+		tangle.ApprovalManager.Events.MessageOrphaned.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			censorshipOutcomeMutex.Lock()
+			defer censorshipOutcomeMutex.Unlock()
+			issuerOutcome, honestIssuer := censorshipOutcomes[message.Issuer]
+			if !honestIssuer || decidedByHonestNodes[message.ID] {
+				return
+			}
+			decidedByHonestNodes[message.ID] = true
+			issuerOutcome.orphaned++
+		}))
+	}
+}
+
+// dumpCensorship writes how the messages of every censored issuer and of all other honest issuers were confirmed or
+// orphaned, next to the weight share of the censors.
+func dumpCensorship(net *network.Network) {
+	censors := adversary.Censors()
+	if len(censors) == 0 {
+		return
+	}
+
+	header := []string{
+		"Issuer",
+		"Censored",
+		"Censor Weight Share",
+		"Issued Messages",
+		"Confirmed Messages",
+		"Orphaned Messages",
+		"Undecided Messages",
+		"Mean Confirmation Time (ns)",
+		"Max Confirmation Time (ns)",
+	}
+	rows := make(csvRows, 0)
+
+	censorWeight := uint64(0)
+	for _, censor := range censors {
+		censorWeight += net.WeightDistribution.Weight(censor.Peer().ID)
+	}
+	censorWeightShare := strconv.FormatFloat(float64(censorWeight)/float64(net.WeightDistribution.TotalWeight()), 'f', 6, 64)
+
+	addOutcome := func(issuer string, censored bool, outcome *censorshipOutcome) {
+		meanConfirmationTime := int64(0)
+		if outcome.confirmed > 0 {
+			meanConfirmationTime = outcome.confirmationTime.Nanoseconds() / outcome.confirmed
+		}
+		record := []string{
+			issuer,
+			strconv.FormatBool(censored),
+			censorWeightShare,
+			strconv.FormatInt(outcome.issued, 10),
+			strconv.FormatInt(outcome.confirmed, 10),
+			strconv.FormatInt(outcome.orphaned, 10),
+			strconv.FormatInt(outcome.issued-outcome.confirmed-outcome.orphaned, 10),
+			strconv.FormatInt(meanConfirmationTime, 10),
+			strconv.FormatInt(outcome.maxConfirmationTime.Nanoseconds(), 10),
+		}
+		rows = append(rows, record)
+	}
+
+	censorshipOutcomeMutex.Lock()
+	defer censorshipOutcomeMutex.Unlock()
+	otherIssuers := &censorshipOutcome{}
+	for peerID := 0; peerID < len(net.Peers); peerID++ {
+		issuerOutcome, honestIssuer := censorshipOutcomes[network.PeerID(peerID)]
+		if !honestIssuer {
+			continue
+		}
+		if adversary.IsCensored(network.PeerID(peerID)) {
+			addOutcome(strconv.Itoa(peerID), true, issuerOutcome)
+		} else {
+			otherIssuers.add(issuerOutcome)
+		}
+	}
+	addOutcome("Others", false, otherIssuers)
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "censorship.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
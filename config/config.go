@@ -35,6 +35,15 @@ var Params = &Config{
 		GeneralOutputDir:                GeneralOutputDir,
 		SchedulerOutputDir:              SchedulerOutputDir,
 		SimulationDuration:              time.Duration(1) * time.Minute,
+		PruningHorizon:                  0,
+		StreamGlobalMetrics:             false,
+		SharedMessageStore:              false,
+		ExportFormats:                   []string{},
+		ExportNodeID:                    0,
+		ExportStartSlot:                 -1,
+		ExportEndSlot:                   -1,
+		ExportStartTime:                 0,
+		ExportEndTime:                   0,
 	},
 	NetworkSettings: &NetworkSettings{
 		CommitteeBandwidth: 0.5,
@@ -55,6 +64,8 @@ var Params = &Config{
 		MaxDelay:           100,
 
 		SlowdownFactor: 1,
+
+		AdaptiveValidatorIssuance: false,
 	},
 	WeightSettings: &WeightSettings{
 		NodesTotalWeight:              100_000_000,
@@ -62,6 +73,17 @@ var Params = &Config{
 		ConfirmationThreshold:         0.66,
 		ConfirmationThresholdAbsolute: true,
 		RelevantValidatorWeight:       0,
+		ApprovalWeightEngine:          "Walk",
+		AcceptanceGadget:              "Threshold",
+		NonValidatorAcceptanceGadget:  "",
+		ConfirmationDepth:             10,
+		ValidatorConfirmations:        3,
+		AcceptanceThreshold:           0.5,
+		OrphanageRule:                 "TooOld",
+		TimestampMaxDrift:             0,
+		TimestampParentOrder:          false,
+		TimestampMaxParentAge:         0,
+		EquivocationPolicy:            "None",
 	},
 	TipSelectionAlgorithmSettings: &TipSelectionAlgorithmSettings{
 		TSA:           "RURTS",
@@ -69,25 +91,67 @@ var Params = &Config{
 		WeakTipsRatio: 0.0,
 	},
 	CongestionControlSettings: &CongestionControlSettings{
-		SchedulerType:     "ICCA+",
-		BurnPolicies:      RandomArrayFromValues(0, []int{0, 1}, NodesCount),
-		InitialMana:       0.0,
-		MaxBuffer:         25,
-		ConfEligible:      true,
-		MaxDeficit:        2.0,
-		SlotTime:          time.Duration(1 * float64(time.Second)),
-		MinCommittableAge: MinCommittableAge,
-		RMCTime:           MinCommittableAge,
-		LowerRMCThreshold: 0.5 * float64(SchedulingRate) * SlotTime.Seconds(),
-		UpperRMCThreshold: 0.75 * float64(SchedulingRate) * SlotTime.Seconds(),
-		AlphaRMC:          0.8,
-		BetaRMC:           1.2,
-		RMCmin:            RMCmin, // 0.25
-		InitialRMC:        RMCmin,
-		RMCmax:            5000000.0, //2.0
-		RMCincrease:       1000000.0, // 1.0
-		RMCdecrease:       500000.0,  // 0.5
-		RMCPeriodUpdate:   30,
+		SchedulerType:      "ICCA+",
+		ManaManagerType:    "",
+		RateSetterType:     "",
+		BurnPolicies:       RandomArrayFromValues(0, []int{0, 1}, NodesCount),
+		InitialMana:        0.0,
+		MaxBuffer:          25,
+		ConfEligible:       true,
+		MaxDeficit:         2.0,
+		SlotTime:           time.Duration(1 * float64(time.Second)),
+		MinCommittableAge:  MinCommittableAge,
+		RMCTime:            MinCommittableAge,
+		LowerRMCThreshold:  0.5 * float64(SchedulingRate) * SlotTime.Seconds(),
+		UpperRMCThreshold:  0.75 * float64(SchedulingRate) * SlotTime.Seconds(),
+		AlphaRMC:           0.8,
+		BetaRMC:            1.2,
+		RMCmin:             RMCmin, // 0.25
+		InitialRMC:         RMCmin,
+		RMCmax:             5000000.0, //2.0
+		RMCincrease:        1000000.0, // 1.0
+		RMCdecrease:        500000.0,  // 0.5
+		RMCPeriodUpdate:    30,
+		AIMDIncrease:       1.0,
+		AIMDDecrease:       0.5,
+		AIMDMinRate:        1.0,
+		AIMDQueueThreshold: 2,
+		AIMDHoldTime:       time.Second,
+		TokenBucketSize:    5,
+		DropPolicy:         "",
+		REDThreshold:       0.5,
+		REDMaxProbability:  0.1,
+		WorkDistribution:   "Constant",
+		MinWork:            1,
+		MaxWork:            1,
+		ValidationWork:     1,
+		ColoredWork:        0,
+
+		RMCController:         "Additive",
+		RMCTargetTraffic:      0,
+		RMCKp:                 1.0,
+		RMCKi:                 0.1,
+		RMCKd:                 0.0,
+		RMCAdjustmentQuotient: 8,
+
+		ManaGenerationRate:      0.01,
+		ManaDecay:               0.0,
+		ManaCap:                 0,
+		ManaTransferProbability: 0.0,
+		ManaTransferFraction:    0.1,
+		ManaAllocationFile:      "",
+		ManaLedgerMode:          "Local",
+
+		BurnHistorySize:     100,
+		BurnFeePercentile:   0.5,
+		BurnDeadline:        5 * time.Second,
+		BurnRandomGreedyMax: 10,
+		BurnBudget:          100,
+		BurnBudgetWindow:    10 * time.Second,
+
+		ValidationScheduling: "Bypass",
+		ValidationLaneRate:   20,
+		ValidationLaneBurst:  5,
 	},
 	AdversarySettings: &AdversarySettings{
 		SimulationMode:   "None",
@@ -102,11 +166,43 @@ var Params = &Config{
 		AdversaryInitColors: []string{"R", "B"},
 		AdversaryPeeringAll: false,
 		AdversarySpeedup:    []float64{1.0, 1.0},
+		AdversaryBehaviors:  []string{},
+
+		AdversaryGossipFraction: 0.5,
+		ParasiteRootAge:         5 * time.Second,
+		ParasiteReleaseDelay:    10 * time.Second,
+		BalancingOmniscient:     false,
+
+		EclipseVictims:         []int{},
+		EclipseMode:            "Delay",
+		EclipseDelay:           2 * time.Second,
+		EclipseDropProbability: 0.5,
+		EclipseAnswerRequests:  true,
+		SybilConnections:       0,
+
+		CensoredIssuers: []int{},
+
+		SpamMode:        "Constant",
+		SpamRate:        50,
+		SpamBurstLength: 2 * time.Second,
+		SpamBurstPeriod: 10 * time.Second,
+		SpamSlots:       []int{},
+		SpamBurn:        "Policy",
+		SpamParents:     "Tips",
+
+		TimestampShiftMin: -10 * time.Second,
+		TimestampShiftMax: -5 * time.Second,
+
+		EquivocationMode: "Parents",
 
-		BlowballMana:    20,
-		BlowballSize:    20,
-		BlowballDelay:   5,
-		BlowballMaxSent: 2,
-		BlowballNodeID:  0,
+		BlowballMana:      20,
+		BlowballSize:      20,
+		BlowballDelay:     5,
+		BlowballMaxSent:   2,
+		BlowballNodeID:    0,
+		BlowballNodeCount: 1,
+		BlowballTarget:    "Oldest",
+		BlowballTargetAge: 5 * time.Second,
+		BlowballRules:     "Violate",
 	},
 }
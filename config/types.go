@@ -34,6 +34,27 @@ type SimulatorSettings struct {
 	GeneralOutputDir   string        `default:"results/20060102_1504/general"`
 	SchedulerOutputDir string        `default:"results/20060102_1504/scheduler"`
 	SimulationDuration time.Duration `default:"1m"`
+	// PruningHorizon is the number of slots behind the accepted tangle time for which messages are kept on every
+	// node, older slots are pruned. 0 disables pruning.
+	PruningHorizon int `default:"0"`
+	// StreamGlobalMetrics writes the per-message results to disk as soon as they are final instead of keeping the
+	// messages in memory until the end of the simulation.
+	StreamGlobalMetrics bool `default:"false"`
+	// SharedMessageStore keeps a single copy of every message and its children for all nodes, the nodes only keep
+	// their local metadata.
+	SharedMessageStore bool `default:"false"`
+	// ExportFormats are the formats the tangle of ExportNodeID is written in at shutdown or on SIGUSR1, any of 'dot',
+	// 'graphml' and 'json'. Empty disables the export.
+	ExportFormats []string
+	// ExportNodeID is the node whose tangle is exported.
+	ExportNodeID int `default:"0"`
+	// ExportStartSlot and ExportEndSlot limit the export to the messages issued in the given slots, -1 is unbounded.
+	ExportStartSlot int `default:"-1"`
+	ExportEndSlot   int `default:"-1"`
+	// ExportStartTime and ExportEndTime limit the export to the messages issued in the given time since the genesis,
+	// 0 is unbounded.
+	ExportStartTime time.Duration `default:"0"`
+	ExportEndTime   time.Duration `default:"0"`
 }
 
 type NetworkSettings struct {
@@ -45,7 +66,10 @@ type NetworkSettings struct {
 	CommitteeBandwidth float64 `default:"0.5"`
 	// ValidatorBPS is the rate of validation blocks simulated in the network per validator node.
 	ValidatorBPS int `default:"1"`
-	// Scheduler rate in units of messages per second.
+	// AdaptiveValidatorIssuance lets validators skip a validation block if no new data message was scheduled since
+	// their last validation block.
+	AdaptiveValidatorIssuance bool `default:"false"`
+	// Scheduler rate in work units per second, a message with a work of 1 is one unit.
 	SchedulingRate int `default:"200"`
 	// Total rate of issuing messages in units of messages per second.
 	IssuingRate int `default:"100"`
@@ -86,6 +110,40 @@ type WeightSettings struct {
 	ConfirmationThresholdAbsolute bool `default:"true"`
 	// The node whose weight * RelevantValidatorWeight <= largestWeight will not issue messages (disabled now)
 	RelevantValidatorWeight int `default:"0"`
+	// ApprovalWeightEngine selects how the approval weight is propagated, one of the following:
+	// 'Walk' - walks the past cone of every validation block up to the messages its issuer already approved,
+	// 'Indexed' - also stops at confirmed and orphaned messages, which then keep the weight they had when they were
+	// decided.
+	ApprovalWeightEngine string `default:"Walk"`
+	// AcceptanceGadget is the finality gadget used by validator nodes, one of the following:
+	// 'Threshold' - messages are confirmed once their AW reaches ConfirmationThreshold,
+	// 'Depth' - messages are confirmed once ConfirmationDepth messages are stacked on top of them,
+	// 'ValidatorK' - messages are confirmed once ValidatorConfirmations distinct validators approve them,
+	// 'TwoPhase' - messages are accepted at AcceptanceThreshold and confirmed once an accepted validation block approves them.
+	AcceptanceGadget string `default:"Threshold"`
+	// NonValidatorAcceptanceGadget is the finality gadget used by nodes without weight, empty to use AcceptanceGadget.
+	NonValidatorAcceptanceGadget string `default:""`
+	// ConfirmationDepth is the number of messages on top of a message needed by the 'Depth' gadget.
+	ConfirmationDepth int `default:"10"`
+	// ValidatorConfirmations is the number of distinct validators approving a message needed by the 'ValidatorK' gadget.
+	ValidatorConfirmations int `default:"3"`
+	// AcceptanceThreshold is the AW threshold for the acceptance phase of the 'TwoPhase' gadget.
+	AcceptanceThreshold float64 `default:"0.5"`
+	// OrphanageRule decides which decided messages are orphaned instead of confirmed, one of the following:
+	// 'TooOld' - messages issued before ATT - MinCommittableAge are orphaned, 'None' - messages are never orphaned.
+	OrphanageRule string `default:"TooOld"`
+	// TimestampMaxDrift rejects received messages whose IssuanceTime is further than this from their arrival, 0
+	// disables the rule.
+	TimestampMaxDrift time.Duration `default:"0s"`
+	// TimestampParentOrder rejects received messages that are older than one of their parents.
+	TimestampParentOrder bool `default:"false"`
+	// TimestampMaxParentAge rejects received messages whose parents are more than this older than them, 0 disables
+	// the rule.
+	TimestampMaxParentAge time.Duration `default:"0s"`
+	// EquivocationPolicy decides how the validators caught issuing two messages with the same sequence number are
+	// treated, one of the following: 'None' - they are only detected, 'Ignore' - their later messages add no approval
+	// weight and no votes, 'Slash' - like 'Ignore' and their last vote is removed from the opinions.
+	EquivocationPolicy string `default:"None"`
 }
 
 // Tip Selection Algorithm setup
@@ -102,10 +160,16 @@ type TipSelectionAlgorithmSettings struct {
 // Congestion Control
 
 type CongestionControlSettings struct {
-	SchedulerType     string `default:"ICCA+"` // ManaBurn or ICCA+
+	SchedulerType string `default:"ICCA+"` // ManaBurn or ICCA+
+	// ManaManagerType is the mana accounting used together with the scheduler, ManaBurn, ICCA+, Stake or None. Empty
+	// uses the one of the SchedulerType.
+	ManaManagerType string `default:""`
+	// RateSetterType is the rate setter used together with the scheduler, ICCA+, AIMD, TokenBucket or None. Empty uses
+	// the one of the SchedulerType.
+	RateSetterType    string `default:""`
 	BurnPolicies      []int
 	InitialMana       float64       `default:"0.0"`
-	MaxBuffer         int           `default:"25"`
+	MaxBuffer         int           `default:"25"`   // maximum work of the ready messages in the buffer
 	ConfEligible      bool          `default:"true"` // if true, then confirmed is used for eligible check. else just scheduled
 	MaxDeficit        float64       `default:"2.0"`  // maximum deficit for any id
 	SlotTime          time.Duration `default:"1s"`
@@ -124,6 +188,89 @@ type CongestionControlSettings struct {
 	RMCincrease       float64 `default:"1.0"`
 	RMCdecrease       float64 `default:"0.5"`
 	RMCPeriodUpdate   int     `default:"5"`
+	// RMCController is the rule used to update the RMC from the traffic of the committed slots, one of the following:
+	// 'Additive' - RMCincrease/RMCdecrease outside of the thresholds, 'Multiplicative' - BetaRMC/AlphaRMC outside of
+	// the thresholds, 'PID' - PID controller targeting RMCTargetTraffic, 'EIP1559' - EIP-1559 style relative update.
+	RMCController string `default:"Additive"`
+	// RMCTargetTraffic is the accepted messages per slot targeted by the PID and EIP1559 controllers, 0 uses the
+	// middle between LowerRMCThreshold and UpperRMCThreshold, which has to be positive then.
+	RMCTargetTraffic float64 `default:"0"`
+	// RMCKp, RMCKi and RMCKd are the gains of the PID controller, the correction is in multiples of RMCincrease.
+	RMCKp float64 `default:"1.0"`
+	RMCKi float64 `default:"0.1"`
+	RMCKd float64 `default:"0.0"`
+	// RMCAdjustmentQuotient bounds the relative change of the EIP1559 controller per update, like the base fee change
+	// denominator of EIP-1559. It is at least 1.
+	RMCAdjustmentQuotient float64 `default:"8"`
+	// AIMDIncrease is the additive increase of the 'AIMD' rate setter, about the increase of the rate in work units per
+	// second every second.
+	AIMDIncrease float64 `default:"1.0"`
+	// AIMDDecrease is the factor the rate of the 'AIMD' rate setter is multiplied with on congestion.
+	AIMDDecrease float64 `default:"0.5"`
+	// AIMDMinRate is the minimum rate of the 'AIMD' rate setter in work units per second.
+	AIMDMinRate float64 `default:"1.0"`
+	// AIMDQueueThreshold is the work of the own issuer queue above which the 'AIMD' rate setter decreases the rate.
+	AIMDQueueThreshold int `default:"2"`
+	// AIMDHoldTime is the time after a decrease of the 'AIMD' rate setter during which the rate is not decreased again.
+	AIMDHoldTime time.Duration `default:"1s"`
+	// TokenBucketSize is the size of the bucket of the 'TokenBucket' rate setter in work units.
+	TokenBucketSize float64 `default:"5"`
+	// DropPolicy selects the message dropped once the buffer exceeds MaxBuffer, one of the following:
+	// 'Head' - the oldest message, 'Tail' - the newest message, 'HeadFromLongest' - the oldest message of the issuer
+	// with the largest load, 'NewestFromLongest' - the newest message of the issuer with the largest load,
+	// 'Burn' - the message with the lowest burn, 'RED' - random early detection of arriving messages.
+	// Empty uses HeadFromLongest for ICCA+ and Burn for ManaBurn.
+	DropPolicy string `default:""`
+	// REDThreshold is the buffer load relative to MaxBuffer above which the 'RED' policy starts dropping.
+	REDThreshold float64 `default:"0.5"`
+	// REDMaxProbability is the drop probability of the 'RED' policy when the buffer is full.
+	REDMaxProbability float64 `default:"0.1"`
+	// WorkDistribution is the distribution of the work of data messages in scheduler work units, one of the following:
+	// 'Constant' - every message has MinWork, 'Uniform' - the work is drawn uniformly from [MinWork, MaxWork].
+	WorkDistribution string `default:"Constant"`
+	MinWork          int    `default:"1"`
+	MaxWork          int    `default:"1"`
+	// ValidationWork is the work of validation blocks.
+	ValidationWork int `default:"1"`
+	// ColoredWork is the work of messages carrying a color, 0 to use the WorkDistribution.
+	ColoredWork int `default:"0"`
+	// ManaGenerationRate is the mana generated per slot by every unit of stake of the 'Stake' mana manager.
+	ManaGenerationRate float64 `default:"0.01"`
+	// ManaDecay is the fraction of the mana of every account that decays per slot.
+	ManaDecay float64 `default:"0.0"`
+	// ManaCap is the maximum mana of an account, 0 for no cap.
+	ManaCap float64 `default:"0"`
+	// ManaTransferProbability is the probability that a data message transfers mana to a random node.
+	ManaTransferProbability float64 `default:"0.0"`
+	// ManaTransferFraction is the fraction of the own mana transferred by a mana transfer.
+	ManaTransferFraction float64 `default:"0.1"`
+	// ManaAllocationFile is a CSV file with the columns 'Node ID,Stake,Mana' giving the stake and the initial mana of
+	// the accounts, empty to use the consensus weight as stake and InitialMana for every account.
+	ManaAllocationFile string `default:""`
+	// ManaLedgerMode defines how the nodes account the mana balances, one of the following: 'Local' - every node
+	// updates its own view as it generates mana and schedules messages, 'Committed' - the balances are derived from the
+	// accepted messages of the committed slots, so all nodes agree.
+	ManaLedgerMode string `default:"Local"`
+	// BurnHistorySize is the number of recently scheduled burns the 'FeeEstimation' burn policy estimates the fee from.
+	BurnHistorySize int `default:"100"`
+	// BurnFeePercentile is the percentile of the recently scheduled burns burnt by the 'FeeEstimation' burn policy.
+	BurnFeePercentile float64 `default:"0.5"`
+	// BurnDeadline is the time without an own scheduled message after which the 'Deadline' burn policy burns all mana.
+	BurnDeadline time.Duration `default:"5s"`
+	// BurnRandomGreedyMax is the maximum amount the 'RandomGreedy' burn policy outbids the highest burn with.
+	BurnRandomGreedyMax float64 `default:"10"`
+	// BurnBudget is the mana the 'Budget' burn policy burns at most within BurnBudgetWindow.
+	BurnBudget float64 `default:"100"`
+	// BurnBudgetWindow is the time window of the BurnBudget.
+	BurnBudgetWindow time.Duration `default:"10s"`
+	// ValidationScheduling defines how the ICCA+ scheduler handles validation blocks, one of the following: 'Bypass' -
+	// validation blocks are scheduled right away, 'Lane' - validation blocks are scheduled with priority over the data
+	// messages from a separate lane that is limited to ValidationLaneRate blocks per second.
+	ValidationScheduling string `default:"Bypass"`
+	// ValidationLaneRate is the rate of validation blocks per second the validation lane schedules at most.
+	ValidationLaneRate float64 `default:"20"`
+	// ValidationLaneBurst is the number of validation blocks the validation lane can schedule in a burst.
+	ValidationLaneBurst float64 `default:"5"`
 }
 
 // Adversary setup - enabled by setting SimulationTarget="DS"
@@ -152,8 +299,62 @@ type AdversarySettings struct {
 	AdversaryPeeringAll bool `default:"false"`
 	// Defines how many more messages should adversary nodes issue.
 	AdversarySpeedup []float64
+	// Defines the adversary behaviors of each group joined by '+', e.g. 'ShiftingOpinion+SelectiveGossip NoGossip'.
+	// Groups without behaviors use the behaviors of their AdversaryTypes.
+	AdversaryBehaviors []string
+	// The fraction of its neighbors an adversary node with the 'SelectiveGossip' behavior gossips to.
+	AdversaryGossipFraction float64 `default:"0.5"`
+	// The age of the message the side-tangle of the 'ParasiteChain' behavior starts from.
+	ParasiteRootAge time.Duration `default:"5s"`
+	// The time after the double spend after which the 'ParasiteChain' behavior releases the withheld side-tangle.
+	ParasiteReleaseDelay time.Duration `default:"10s"`
+	// Defines whether the 'Balancing' behavior reads the opinions of its neighbors from their nodes instead of
+	// estimating them from their last votes.
+	BalancingOmniscient bool `default:"false"`
+	// The IDs of the honest nodes whose neighbors are replaced by the adversary nodes with the 'Eclipse' behavior.
+	EclipseVictims []int
+	// Defines how the 'Eclipse' behavior treats the messages the victims see and forward, one of the following:
+	// 'Delay' - delays every message by EclipseDelay, 'Reorder' - delays every message by a random time up to
+	// EclipseDelay, 'Drop' - drops messages with EclipseDropProbability.
+	EclipseMode string `default:"Delay"`
+	// The delay the 'Eclipse' behavior adds to the messages of the victims.
+	EclipseDelay time.Duration `default:"2s"`
+	// The probability that the 'Eclipse' behavior drops a message of the victims.
+	EclipseDropProbability float64 `default:"0.5"`
+	// Defines whether the 'Eclipse' behavior answers the requests of the victims for missing messages.
+	EclipseAnswerRequests bool `default:"true"`
+	// The number of additional honest nodes every node with the 'Sybil' behavior is connected to, each connection
+	// takes the neighbor slot of an honest node.
+	SybilConnections int `default:"0"`
+	// The IDs of the issuers whose messages are censored by the 'CensorTips' and 'CensorScheduler' behaviors.
+	CensoredIssuers []int
+	// Defines when the 'Spam' behavior spams, one of the following: 'Constant' - all the time, 'Bursty' - for
+	// SpamBurstLength in every SpamBurstPeriod, 'Slot' - only in the SpamSlots.
+	SpamMode string `default:"Constant"`
+	// The rate of the spam of every node with the 'Spam' behavior in messages per second, on top of its issuing rate.
+	SpamRate float64 `default:"50"`
+	// The length of the bursts of the 'Bursty' SpamMode.
+	SpamBurstLength time.Duration `default:"2s"`
+	// The period of the bursts of the 'Bursty' SpamMode.
+	SpamBurstPeriod time.Duration `default:"10s"`
+	// The indices of the slots spammed in the 'Slot' SpamMode.
+	SpamSlots []int
+	// Defines the mana the spam burns, one of the following: 'Policy' - the burn of the burn policy of the node,
+	// 'Minimal' - the RMC of the slot, 'Zero' - nothing. The spam is issued even if the node does not have the mana.
+	SpamBurn string `default:"Policy"`
+	// Defines the parents of the spam, one of the following: 'Tips' - the tips of the node, 'Unsolid' - the tips and a
+	// message that never exists, 'Unready' - only the previous spam message.
+	SpamParents string `default:"Tips"`
+	// The minimum shift of the IssuanceTime of the messages of the 'Timestamps' behavior, negative shifts back-date.
+	TimestampShiftMin time.Duration `default:"-10s"`
+	// The maximum shift of the IssuanceTime of the messages of the 'Timestamps' behavior, positive shifts future-date.
+	TimestampShiftMax time.Duration `default:"-5s"`
 
-	// The mana of the blowball node in % of total mana
+	// Defines how the two validation blocks of the 'Equivocation' behavior conflict, one of the following: 'Parents' -
+	// the second block approves older parents, 'Colors' - the blocks approve the tips of different colors.
+	EquivocationMode string `default:"Parents"`
+
+	// The mana of all blowball nodes together in % of total mana
 	BlowballMana int `default:"20"`
 	// The size of the blowball
 	BlowballSize int `default:"20"`
@@ -161,6 +362,17 @@ type AdversarySettings struct {
 	BlowballDelay int `default:"5"`
 	// The maximum number of blowballs sent to the network
 	BlowballMaxSent int `default:"2"`
-	// The node ID of the blowball node
+	// The node ID of the first blowball node
 	BlowballNodeID int `default:"0"`
+	// The number of blowball nodes in the 'Blowball' SimulationMode, they take the IDs following BlowballNodeID
+	BlowballNodeCount int `default:"1"`
+	// Defines the message approved by the blowballs, one of the following: 'Oldest' - the oldest unconfirmed message,
+	// 'Age' - the unconfirmed message whose age is the closest to BlowballTargetAge.
+	BlowballTarget string `default:"Oldest"`
+	// The age of the message approved by the blowballs with the 'Age' BlowballTarget.
+	BlowballTargetAge time.Duration `default:"5s"`
+	// Defines whether the blowballs follow the rules of the honest nodes, one of the following: 'Respect' - the
+	// messages burn mana and are gossiped by the scheduler, 'Violate' - the messages burn no mana and are gossiped
+	// right away, bypassing the own scheduler.
+	BlowballRules string `default:"Violate"`
 }
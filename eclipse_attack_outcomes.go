@@ -0,0 +1,194 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region eclipse attack ///////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	eclipseOutcome      = &eclipseAttackOutcome{firstConfirmationTimes: make(map[multiverse.MessageID]time.Time), victims: make(map[network.PeerID]*eclipseVictimOutcome)}
+	eclipseOutcomeMutex sync.Mutex
+)
+
+// eclipseAttackOutcome compares the confirmations of the victims of the 'Eclipse' attackers with the rest of the honest
+// nodes.
+type eclipseAttackOutcome struct {
+	// firstConfirmationTimes are the times the messages were first confirmed by an honest node that is no victim
+	firstConfirmationTimes map[multiverse.MessageID]time.Time
+	victims                map[network.PeerID]*eclipseVictimOutcome
+}
+
+// eclipseVictimOutcome tracks the confirmations, the opinion and the requests of a victim.
+type eclipseVictimOutcome struct {
+	confirmed map[multiverse.MessageID]bool
+	// confirmationsAhead are the confirmations of the victim before any other honest node
+	confirmationsAhead int64
+	confirmationLag    time.Duration
+	maxConfirmationLag time.Duration
+	opinionChanges     int64
+	requested          map[multiverse.MessageID]bool
+	requests           int64
+	recovered          int64
+}
+
+// monitorEclipseAttack records when the victims confirm messages compared to the other honest nodes, how often they
+// change their opinion and whether the Requester recovers the messages the attackers withhold.
+func monitorEclipseAttack(net *network.Network) {
+	if len(adversary.EclipseAttackers()) == 0 {
+		return
+	}
+
+	for _, victimID := range config.Params.EclipseVictims {
+		if victimID >= 0 && victimID < len(net.Peers) && !network.IsAdversary(victimID) {
+			eclipseOutcome.victims[network.PeerID(victimID)] = &eclipseVictimOutcome{
+				confirmed: make(map[multiverse.MessageID]bool),
+				requested: make(map[multiverse.MessageID]bool),
+			}
+		}
+	}
+
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		victimOutcome, isVictim := eclipseOutcome.victims[peer.ID]
+		if !isVictim {
+			tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+				eclipseOutcomeMutex.Lock()
+				defer eclipseOutcomeMutex.Unlock()
+				if _, exists := eclipseOutcome.firstConfirmationTimes[message.ID]; !exists {
+					eclipseOutcome.firstConfirmationTimes[message.ID] = messageMetadata.ConfirmationTime()
+				}
+			}))
+			continue
+		}
+
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			eclipseOutcomeMutex.Lock()
+			defer eclipseOutcomeMutex.Unlock()
+			victimOutcome.confirmed[message.ID] = true
+			firstConfirmationTime, exists := eclipseOutcome.firstConfirmationTimes[message.ID]
+			if !exists {
+				victimOutcome.confirmationsAhead++
+				return
+			}
+			lag := messageMetadata.ConfirmationTime().Sub(firstConfirmationTime)
+			victimOutcome.confirmationLag += lag
+			if lag > victimOutcome.maxConfirmationLag {
+				victimOutcome.maxConfirmationLag = lag
+			}
+		}))
+		tangle.OpinionManager.Events().OpinionChanged.Attach(events.NewClosure(func(oldOpinion multiverse.Color, newOpinion multiverse.Color, weight int64) {
+			eclipseOutcomeMutex.Lock()
+			defer eclipseOutcomeMutex.Unlock()
+			victimOutcome.opinionChanges++
+		}))
+		tangle.Requester.Events.Request.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
+			eclipseOutcomeMutex.Lock()
+			defer eclipseOutcomeMutex.Unlock()
+			victimOutcome.requested[messageID] = true
+			victimOutcome.requests++
+		}))
+		tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID multiverse.MessageID, message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+			eclipseOutcomeMutex.Lock()
+			defer eclipseOutcomeMutex.Unlock()
+			if victimOutcome.requested[messageID] {
+				delete(victimOutcome.requested, messageID)
+				victimOutcome.recovered++
+			}
+		}))
+	}
+}
+
+// dumpEclipseAttack writes the confirmation lag, the opinion and the recovered requests of every victim, next to the
+// messages the eclipse attackers delayed or dropped and the requests they ignored.
+func dumpEclipseAttack(net *network.Network) {
+	if len(adversary.EclipseAttackers()) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Weight Share",
+		"Neighbors",
+		"Eclipse Mode",
+		"Confirmed Messages",
+		"Confirmations Ahead Of Honest Nodes",
+		"Messages Only Confirmed By Honest Nodes",
+		"Mean Confirmation Lag (ns)",
+		"Max Confirmation Lag (ns)",
+		"Opinion",
+		"Honest Majority Opinion",
+		"Divergent Opinion",
+		"Opinion Changes",
+		"Requests",
+		"Recovered Messages",
+		"Unrecovered Messages",
+		"Delayed Messages",
+		"Dropped Messages",
+		"Ignored Requests",
+	}
+	rows := make(csvRows, 0)
+
+	var delayedMessages, droppedMessages, ignoredRequests int
+	for _, eclipseAttacker := range adversary.EclipseAttackers() {
+		delayed, dropped, ignored := eclipseAttacker.Stats()
+		delayedMessages += delayed
+		droppedMessages += dropped
+		ignoredRequests += ignored
+	}
+
+	eclipseOutcomeMutex.Lock()
+	defer eclipseOutcomeMutex.Unlock()
+	for victimID, victimOutcome := range eclipseOutcome.victims {
+		victim := net.Peer(int(victimID))
+		opinion := victim.Node.(multiverse.NodeInterface).Tangle().OpinionManager.Opinion()
+		onlyConfirmedByHonestNodes := 0
+		for messageID := range eclipseOutcome.firstConfirmationTimes {
+			if !victimOutcome.confirmed[messageID] {
+				onlyConfirmedByHonestNodes++
+			}
+		}
+		lagged := int64(len(victimOutcome.confirmed)) - victimOutcome.confirmationsAhead
+		meanConfirmationLag := int64(0)
+		if lagged > 0 {
+			meanConfirmationLag = victimOutcome.confirmationLag.Nanoseconds() / lagged
+		}
+		record := []string{
+			strconv.FormatInt(int64(victimID), 10),
+			strconv.FormatFloat(float64(net.WeightDistribution.Weight(victimID))/float64(net.WeightDistribution.TotalWeight()), 'f', 6, 64),
+			strconv.Itoa(len(victim.Neighbors)),
+			config.Params.EclipseMode,
+			strconv.Itoa(len(victimOutcome.confirmed)),
+			strconv.FormatInt(victimOutcome.confirmationsAhead, 10),
+			strconv.Itoa(onlyConfirmedByHonestNodes),
+			strconv.FormatInt(meanConfirmationLag, 10),
+			strconv.FormatInt(victimOutcome.maxConfirmationLag.Nanoseconds(), 10),
+			opinion.String(),
+			honestOnlyMostLikedColor.String(),
+			strconv.FormatBool(opinion != honestOnlyMostLikedColor),
+			strconv.FormatInt(victimOutcome.opinionChanges, 10),
+			strconv.FormatInt(victimOutcome.requests, 10),
+			strconv.FormatInt(victimOutcome.recovered, 10),
+			strconv.Itoa(len(victimOutcome.requested)),
+			strconv.Itoa(delayedMessages),
+			strconv.Itoa(droppedMessages),
+			strconv.Itoa(ignoredRequests),
+		}
+		rows = append(rows, record)
+	}
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "eclipseAttack.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region equivocation /////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	equivocationOutcome      = &equivocationAttackOutcome{detections: make(map[network.PeerID][]time.Time), decidedMessages: make(map[multiverse.MessageID]bool)}
+	equivocationOutcomeMutex sync.Mutex
+)
+
+// equivocationAttackOutcome tracks when the honest nodes detected every equivocator and how fast the messages of the
+// honest nodes were confirmed in the meantime.
+type equivocationAttackOutcome struct {
+	// detections are the times the honest nodes detected every equivocator
+	detections map[network.PeerID][]time.Time
+	// decidedMessages are the honest messages that were already confirmed by an honest node
+	decidedMessages  map[multiverse.MessageID]bool
+	confirmed        int64
+	confirmationTime time.Duration
+}
+
+// monitorEquivocation records the detections of the equivocators and the first confirmation of every honest message
+// at the honest nodes, if there are equivocators.
+func monitorEquivocation(net *network.Network) {
+	if len(adversary.Equivocators()) == 0 {
+		return
+	}
+
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		tangle.EquivocationDetector.Events.EquivocationDetected.Attach(events.NewClosure(func(equivocator network.PeerID, sequenceNumber uint64) {
+			equivocationOutcomeMutex.Lock()
+			defer equivocationOutcomeMutex.Unlock()
+			equivocationOutcome.detections[equivocator] = append(equivocationOutcome.detections[equivocator], time.Now())
+		}))
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			if message.Validation || network.IsAdversary(int(message.Issuer)) {
+				return
+			}
+			equivocationOutcomeMutex.Lock()
+			defer equivocationOutcomeMutex.Unlock()
+			if equivocationOutcome.decidedMessages[message.ID] {
+				return
+			}
+			equivocationOutcome.decidedMessages[message.ID] = true
+			equivocationOutcome.confirmed++
+			equivocationOutcome.confirmationTime += messageMetadata.ConfirmationTime().Sub(message.IssuanceTime)
+		}))
+	}
+}
+
+// dumpEquivocation writes for every equivocator how many honest nodes detected it and how long after its first
+// equivocation, next to the confirmations of the honest messages under the EquivocationPolicy.
+func dumpEquivocation(net *network.Network) {
+	equivocators := adversary.Equivocators()
+	if len(equivocators) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Weight",
+		"Equivocation Policy",
+		"Equivocations",
+		"Detecting Honest Nodes",
+		"Honest Nodes",
+		"First Detection Delay (ns)",
+		"Mean Detection Delay (ns)",
+		"Last Detection Delay (ns)",
+		"Confirmed Honest Messages",
+		"Mean Honest Confirmation Time (ns)",
+	}
+	rows := make(csvRows, 0)
+
+	honestNodesCount := 0
+	for _, peer := range net.Peers {
+		if !network.IsAdversary(int(peer.ID)) {
+			honestNodesCount++
+		}
+	}
+
+	equivocationOutcomeMutex.Lock()
+	defer equivocationOutcomeMutex.Unlock()
+	meanConfirmationTime := int64(0)
+	if equivocationOutcome.confirmed > 0 {
+		meanConfirmationTime = equivocationOutcome.confirmationTime.Nanoseconds() / equivocationOutcome.confirmed
+	}
+	for _, equivocator := range equivocators {
+		peerID := equivocator.Node().Peer().ID
+		equivocations, firstEquivocation := equivocator.Equivocations()
+
+		// the delays stay -1 if no honest node detected the equivocator
+		firstDelay, meanDelay, lastDelay := time.Duration(-1), time.Duration(-1), time.Duration(-1)
+		if detections := equivocationOutcome.detections[peerID]; len(detections) > 0 {
+			totalDelay := time.Duration(0)
+			for _, detection := range detections {
+				delay := detection.Sub(firstEquivocation)
+				if firstDelay < 0 || delay < firstDelay {
+					firstDelay = delay
+				}
+				if delay > lastDelay {
+					lastDelay = delay
+				}
+				totalDelay += delay
+			}
+			meanDelay = totalDelay / time.Duration(len(detections))
+		}
+
+		record := []string{
+			strconv.FormatInt(int64(peerID), 10),
+			strconv.FormatUint(net.WeightDistribution.Weight(peerID), 10),
+			config.Params.EquivocationPolicy,
+			strconv.Itoa(equivocations),
+			strconv.Itoa(len(equivocationOutcome.detections[peerID])),
+			strconv.Itoa(honestNodesCount),
+			strconv.FormatInt(firstDelay.Nanoseconds(), 10),
+			strconv.FormatInt(meanDelay.Nanoseconds(), 10),
+			strconv.FormatInt(lastDelay.Nanoseconds(), 10),
+			strconv.FormatInt(equivocationOutcome.confirmed, 10),
+			strconv.FormatInt(meanConfirmationTime, 10),
+		}
+		rows = append(rows, record)
+	}
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "equivocation.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
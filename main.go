@@ -5,19 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/rand"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/iotaledger/multivers-simulation/adversary"
 	"github.com/iotaledger/multivers-simulation/simulation"
-	"github.com/iotaledger/multivers-simulation/singlenodeattacks"
 
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/types"
@@ -94,31 +96,46 @@ var (
 	fullyConfirmedMessageMetadata    = make(map[multiverse.MessageID]*multiverse.MessageMetadata)
 	partiallyConfirmedMessageCounter = make([]int64, config.Params.NodesCount)
 	unconfirmedMessageCounter        = make([]int64, config.Params.NodesCount)
+	droppedMessageCounter            = make([]int64, config.Params.NodesCount)
+	droppedMessageMutex              sync.RWMutex
 	shutdownGlobalMetrics            = make(chan struct{})
 
 	localMetrics        = make(map[string]map[network.PeerID]float64)
 	localResultsWriters = make(map[string]*csv.Writer)
 	localMetricsMutex   sync.RWMutex
+
+	// streamed global metrics, used instead of the message maps above if StreamGlobalMetrics is set
+	pendingBlocks     = make(map[multiverse.MessageID]*blockRecord)
+	decidedMessageMap = make(map[multiverse.MessageID]int)
+	streamWriters     = make(map[string]*csv.Writer)
+	streamMutex       sync.Mutex
 )
 
+// blockRecord holds the data of a message needed for BlockInformation.csv until its row is streamed.
+type blockRecord struct {
+	issuer       network.PeerID
+	issuanceTime time.Time
+}
+
 func main() {
 	log.Info("Starting simulation ... [DONE]")
 	defer log.Info("Shutting down simulation ... [DONE]")
 	simulation.ParseFlags()
 
-	nodeFactories := map[network.AdversaryType]network.NodeFactory{
-		network.HonestNode:     network.NodeClosure(multiverse.NewNode),
-		network.ShiftOpinion:   network.NodeClosure(adversary.NewShiftingOpinionNode),
-		network.TheSameOpinion: network.NodeClosure(adversary.NewSameOpinionNode),
-		network.NoGossip:       network.NodeClosure(adversary.NewNoGossipNode),
-		network.Blowball:       network.NodeClosure(singlenodeattacks.NewBlowballNode),
-	}
+	// the per-issuer counters have to match the number of nodes given on the command line
+	disseminatedMessageCounter = make([]int64, config.Params.NodesCount)
+	undisseminatedMessageCounter = make([]int64, config.Params.NodesCount)
+	fullyConfirmedMessageCounter = make([]int64, config.Params.NodesCount)
+	partiallyConfirmedMessageCounter = make([]int64, config.Params.NodesCount)
+	unconfirmedMessageCounter = make([]int64, config.Params.NodesCount)
+	droppedMessageCounter = make([]int64, config.Params.NodesCount)
 
 	// The simulation start time
 	simulationStartTime = time.Now()
 	testNetwork := network.New(
 		network.Nodes(config.Params.NodesCount,
-			nodeFactories,
+			network.NodeClosure(multiverse.NewNode),
+			adversary.NewAdversaryNode,
 			network.ZIPFDistribution(config.Params.ZipfParameter),
 			network.MixedZIPFDistribution(config.Params.ZipfParameter)),
 		network.Delay(time.Duration(config.Params.SlowdownFactor)*time.Duration(config.Params.MinDelay)*time.Millisecond,
@@ -127,6 +144,8 @@ func main() {
 		network.Topology(network.WattsStrogatz(config.Params.NeighbourCountWS, config.Params.RandomnessWS)),
 		network.AdversaryPeeringAll(config.Params.AdversaryPeeringAll),
 		network.AdversarySpeedup(config.Params.AdversarySpeedup),
+		network.EclipseVictims(config.Params.EclipseVictims),
+		network.SybilConnections(config.Params.SybilConnections),
 		network.GenesisTime(simulationStartTime),
 	)
 	// MetricsMgr = simulation.NewMetricsManager()
@@ -144,6 +163,27 @@ func main() {
 	dumpNetworkConfig(testNetwork)
 	// Start monitoring global metrics
 	monitorGlobalMetrics(testNetwork)
+	// Start monitoring the side-tangles of the parasite chain attackers
+	monitorParasiteChains(testNetwork)
+	// Start monitoring the honest opinions targeted by the balancing attackers
+	monitorBalancingAttack(testNetwork)
+	// Start monitoring the victims of the eclipse attackers
+	monitorEclipseAttack(testNetwork)
+	// Start monitoring the messages of the issuers targeted by the censors
+	monitorCensorship(testNetwork)
+	// Start monitoring how the spam affects the messages of the honest nodes
+	monitorSpam(testNetwork)
+	// Start monitoring the messages with manipulated timestamps
+	monitorTimestamps(testNetwork)
+	// Start monitoring when the honest nodes detect the equivocating validators
+	monitorEquivocation(testNetwork)
+	// Start monitoring the tip pools and the confirmations around the blowballs
+	monitorBlowball(testNetwork)
+	// Start monitoring the throughput of the Sybil identities at the honest nodes
+	monitorSybilAttack(testNetwork)
+
+	// export the tangle of the chosen node whenever SIGUSR1 is received
+	handleTangleExportSignal(testNetwork)
 
 	// start a go routine for each node to start issuing messages
 	startIssuingMessages(testNetwork)
@@ -168,13 +208,8 @@ func main() {
 
 func startProcessingMessages(n *network.Network) {
 	for _, peer := range n.Peers {
-		// The Blowball attacker does not need to process the message
-		// TODO: Also disable `processMessages` for other attackers which do not require it.
-		// todo not sure if processing message should be disabled, as node needs to have complete tangle to walk
-		if !(config.Params.SimulationMode == "Blowball" &&
-			network.IsAttacker(int(peer.ID))) {
-			go processMessages(peer)
-		}
+		// the blowball nodes process the messages as well, as they need the tangle to find the unconfirmed messages
+		go processMessages(peer)
 	}
 }
 
@@ -200,14 +235,12 @@ func processMessages(peer *network.Peer) {
 		case <-ticker.C:
 
 			// Trigger the scheduler to pop messages and gossip them
-			peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.IncrementAccessMana(float64(config.Params.SchedulingRate))
+			peer.Node.(multiverse.NodeInterface).Tangle().ManaManager.IncrementAccessMana(float64(config.Params.SchedulingRate))
 			peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.ScheduleMessage()
 			monitorLocalMetrics(peer)
 		case <-validatorTicker.C:
-			if int(peer.ID) <= config.Params.ValidatorCount {
-				if message, ok := peer.Node.(multiverse.NodeInterface).Tangle().MessageFactory.CreateMessage(true, multiverse.UndefinedColor); ok {
-					peer.Node.(multiverse.NodeInterface).Tangle().ProcessMessage(message)
-				}
+			if int(peer.ID) <= config.Params.ValidatorCount && peer.Node.(multiverse.NodeInterface).Tangle().MessageFactory.ValidationNeeded() {
+				peer.Node.(multiverse.NodeInterface).IssueValidation()
 			}
 		}
 	}
@@ -231,35 +264,14 @@ func SimulateAdversarialBehaviour(testNetwork *network.Network) {
 
 			for _, nodeID := range group.NodeIDs {
 				peer := testNetwork.Peer(nodeID)
-				// honest node does not implement adversary behavior interface
-				if group.AdversaryType != network.HonestNode {
-					node := adversary.CastAdversary(peer.Node)
-					node.AssignColor(color)
-				}
+				// the behaviors of the group decide whether the node takes over the color, honest nodes ignore it
+				peer.Node.(multiverse.NodeInterface).AssignColor(color)
 				go sendMessage(peer, color)
 				log.Infof("Peer %d sent double spend msg: %v", peer.ID, color)
 			}
 		}
 	case "Blowball":
-		ticker := time.NewTicker(time.Duration(config.Params.SlowdownFactor*config.Params.BlowballDelay) * time.Second)
-		alreadySentCounter := 0
-		for {
-			if alreadySentCounter == config.Params.BlowballMaxSent {
-				ticker.Stop()
-				break
-			}
-			select {
-			case <-ticker.C:
-				for _, group := range testNetwork.AdversaryGroups {
-					for _, nodeID := range group.NodeIDs {
-						peer := testNetwork.Peer(nodeID)
-						go sendMessage(peer, multiverse.UndefinedColor)
-						alreadySentCounter++
-					}
-				}
-			}
-		}
-
+		// the blowball nodes replace one of their data messages by a blowball every BlowballDelay
 	}
 }
 
@@ -283,6 +295,9 @@ func startIssuingMessages(testNetwork *network.Network) {
 		//fmt.Printf("speedup %f band %f\n", peer.AdversarySpeedup, band)
 		go issueMessages(peer, band)
 	}
+	for _, spammer := range adversary.Spammers() {
+		go spammer.Spam(simulationStartTime)
+	}
 }
 
 func issueMessages(peer *network.Peer, band float64) {
@@ -291,10 +306,18 @@ func issueMessages(peer *network.Peer, band float64) {
 
 	pace := time.Duration(float64(time.Second) * float64(config.Params.SlowdownFactor) / band)
 
-	if pace == time.Duration(0) {
+	if pace <= time.Duration(0) {
 		log.Warn("Peer ID: ", peer.ID, " has 0 pace!")
 		return
 	}
+	// rate setters that pace the issuance themselves are offered a message at every scheduler tick, so their rate is
+	// not capped by the bandwidth of the node
+	tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+	_, paced := tangle.RateSetter.(multiverse.PacedRateSetter)
+	paced = paced && !tangle.Hooks.RateSetterBypassed
+	if paced {
+		pace = time.Duration(float64(time.Second) * float64(config.Params.SlowdownFactor) / float64(config.Params.SchedulingRate))
+	}
 	ticker := time.NewTicker(pace)
 	congestionTicker := time.NewTicker(time.Duration(config.Params.SlowdownFactor) * config.Params.SimulationDuration / time.Duration(len(config.Params.CongestionPeriods)))
 	defer ticker.Stop()
@@ -308,7 +331,7 @@ func issueMessages(peer *network.Peer, band float64) {
 			log.Warn("Peer ID: ", peer.ID, " has been shutdown!")
 			return
 		case <-ticker.C:
-			if config.Params.IMIF == "poisson" {
+			if config.Params.IMIF == "poisson" && !paced {
 				pace = time.Duration(float64(time.Second) * float64(config.Params.SlowdownFactor) * rand.ExpFloat64() / band)
 				if pace > 0 {
 					ticker.Reset(pace)
@@ -317,7 +340,8 @@ func issueMessages(peer *network.Peer, band float64) {
 
 			// TODO: for attackers, they don't use the rate setter but will issue as many as blocks to fill up the network traffic
 			//       and they will use higher-frequency ticker to issue more blocks
-			if peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.RateSetter() {
+			// nodes with the Speedup behavior issue at their full pace regardless of the rate setter
+			if tangle.Hooks.RateSetterBypassed || tangle.RateSetter.CanIssue() {
 				sendMessage(peer)
 			}
 
@@ -342,14 +366,86 @@ func sendMessage(peer *network.Peer, optionalColor ...multiverse.Color) {
 }
 
 func shutdownSimulation(net *network.Network) {
+	exportTangle(net)
 	net.Shutdown()
 	close(shutdownGlobalMetrics)
 	dumpAcceptanceLatencyAmongNodes()
 	dumpFinalData(net)
+	dumpBurnPolicyOutcomes()
+	dumpManaBalances()
+	dumpSchedulingDelays(net)
+	dumpParasiteChains(net)
+	dumpBalancingAttack(net)
+	dumpEclipseAttack(net)
+	dumpCensorship(net)
+	dumpSpam(net)
+	dumpTimestamps(net)
+	dumpEquivocation(net)
+	dumpBlowball()
+	dumpSybilAttack(net)
 	simulationWg.Wait()
 	//dumpAllMessageMetaData(net.Peers[0].Node.(multiverse.NodeInterface).Tangle().Storage)
 }
 
+// region tangle export ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tangleExportTimeout is the time the final tangle export waits for the exported node to write its tangle.
+const tangleExportTimeout = time.Minute
+
+func tangleExportRequest(name string) *multiverse.ExportRequest {
+	return &multiverse.ExportRequest{
+		Formats:   config.Params.ExportFormats,
+		Directory: path.Join(config.Params.ResultDir, config.Params.ScriptStartTimeStr, "tangle"),
+		Name:      name,
+		StartSlot: multiverse.SlotIndex(config.Params.ExportStartSlot),
+		EndSlot:   multiverse.SlotIndex(config.Params.ExportEndSlot),
+		StartTime: config.Params.ExportStartTime,
+		EndTime:   config.Params.ExportEndTime,
+	}
+}
+
+// handleTangleExportSignal sends an export request to the socket of the exported node on every SIGUSR1, so the tangle
+// is exported by the goroutine processing the messages of the node.
+func handleTangleExportSignal(net *network.Network) {
+	if len(config.Params.ExportFormats) == 0 {
+		return
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	go func() {
+		for range signals {
+			peer := net.Peer(config.Params.ExportNodeID)
+			name := fmt.Sprintf("node-%d-%d", peer.ID, time.Since(simulationStartTime).Milliseconds())
+			log.Infof("Exporting the tangle of node %d to %s", peer.ID, name)
+			peer.ReceiveNetworkMessage(tangleExportRequest(name))
+		}
+	}()
+}
+
+// exportTangle sends the final export request to the chosen node and waits until the goroutine of the node wrote the
+// tangle, so it must be called before the network is shut down.
+func exportTangle(net *network.Network) {
+	if len(config.Params.ExportFormats) == 0 {
+		return
+	}
+
+	peer := net.Peer(config.Params.ExportNodeID)
+	request := tangleExportRequest(fmt.Sprintf("node-%d-final", peer.ID))
+	request.Done = make(chan error, 1)
+	peer.ReceiveNetworkMessage(request)
+	select {
+	case err := <-request.Done:
+		if err != nil {
+			log.Error("Failed to export the tangle: ", err)
+		}
+	case <-time.After(tangleExportTimeout):
+		log.Errorf("Failed to export the tangle: node %d did not answer within %s", peer.ID, tangleExportTimeout)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func monitorLocalMetrics(peer *network.Peer) {
 	localMetricsMutex.Lock()
 	defer localMetricsMutex.Unlock()
@@ -357,15 +453,21 @@ func monitorLocalMetrics(peer *network.Peer) {
 	if len(localMetrics) != 0 {
 		localMetrics["Ready Lengths"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.ReadyLen())
 		localMetrics["Non Ready Lengths"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.NonReadyLen())
-		localMetrics["Own Mana"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.GetNodeAccessMana(peer.ID))
+		localMetrics["Own Mana"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().ManaManager.GetNodeAccessMana(peer.ID))
 		localMetrics["Tips"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().TipManager.TipSet(0).Size())
 		localMetrics["Price"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.GetMaxManaBurn())
 		currentSlotIndex := peer.Node.(multiverse.NodeInterface).Tangle().Storage.SlotIndex(time.Now())
 		localMetrics["RMC"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Storage.RMC(currentSlotIndex))
 		localMetrics["Time since ATT"][peer.ID] = float64(time.Since(peer.Node.(multiverse.NodeInterface).Tangle().Storage.ATT).Seconds())
+		localMetrics["Issuing Rate"][peer.ID] = peer.Node.(multiverse.NodeInterface).Tangle().MessageFactory.IssuingRate()
+		if rateSetter, ok := peer.Node.(multiverse.NodeInterface).Tangle().RateSetter.(multiverse.PacedRateSetter); ok {
+			localMetrics["Rate Setter Rate"][peer.ID] = rateSetter.Rate()
+		}
+		localMetrics["Stored Messages"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Storage.MessagesCount())
+		localMetrics["Approval Weight Processing Time"][peer.ID] = float64(peer.Node.(multiverse.NodeInterface).Tangle().ApprovalManager.ProcessingTime()) / float64(time.Millisecond)
 		if peer.ID == 0 {
 			for i := 0; i < config.Params.NodesCount; i++ {
-				localMetrics["Mana at Node 0"][network.PeerID(i)] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.GetNodeAccessMana(network.PeerID(i)))
+				localMetrics["Mana at Node 0"][network.PeerID(i)] = float64(peer.Node.(multiverse.NodeInterface).Tangle().ManaManager.GetNodeAccessMana(network.PeerID(i)))
 				localMetrics["Issuer Queue Lengths at Node 0"][network.PeerID(i)] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.IssuerQueueLen(network.PeerID(i)))
 				localMetrics["Deficits at Node 0"][network.PeerID(i)] = float64(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.Deficit(network.PeerID(i)))
 			}
@@ -381,6 +483,12 @@ func monitorLocalMetrics(peer *network.Peer) {
 		localMetrics["Issuer Queue Lengths at Node 0"] = make(map[network.PeerID]float64)
 		localMetrics["Deficits at Node 0"] = make(map[network.PeerID]float64)
 		localMetrics["Time since ATT"] = make(map[network.PeerID]float64)
+		localMetrics["Approval Weight Processing Time"] = make(map[network.PeerID]float64)
+		localMetrics["Stored Messages"] = make(map[network.PeerID]float64)
+		localMetrics["Issuing Rate"] = make(map[network.PeerID]float64)
+		if config.Params.RateSetterType == "AIMD" {
+			localMetrics["Rate Setter Rate"] = make(map[network.PeerID]float64)
+		}
 	}
 }
 
@@ -423,7 +531,7 @@ func dumpLocalMetrics() {
 	}
 }
 
-func dumpGlobalMetrics(dissemResultsWriter, undissemResultsWriter, confirmationResultsWriter, partialConfirmationResultsWriter, unconfirmationResultsWriter *csv.Writer) {
+func dumpGlobalMetrics(dissemResultsWriter, undissemResultsWriter, confirmationResultsWriter, partialConfirmationResultsWriter, unconfirmationResultsWriter, droppedResultsWriter *csv.Writer) {
 	simulationWg.Add(1)
 	defer simulationWg.Done()
 	timeSinceStart := time.Since(simulationStartTime).Nanoseconds()
@@ -485,6 +593,16 @@ func dumpGlobalMetrics(dissemResultsWriter, undissemResultsWriter, confirmationR
 	if err := unconfirmationResultsWriter.Write(record); err != nil {
 		panic(err)
 	}
+	droppedMessageMutex.RLock()
+	record = make([]string, config.Params.NodesCount+1)
+	for id := 0; id < config.Params.NodesCount; id++ {
+		record[id] = strconv.FormatInt(droppedMessageCounter[id], 10)
+	}
+	droppedMessageMutex.RUnlock()
+	record[config.Params.NodesCount] = timeStr
+	if err := droppedResultsWriter.Write(record); err != nil {
+		panic(err)
+	}
 
 	// Flush the results writer to avoid truncation.
 	dissemResultsWriter.Flush()
@@ -492,6 +610,7 @@ func dumpGlobalMetrics(dissemResultsWriter, undissemResultsWriter, confirmationR
 	confirmationResultsWriter.Flush()
 	partialConfirmationResultsWriter.Flush()
 	unconfirmationResultsWriter.Flush()
+	droppedResultsWriter.Flush()
 }
 
 func monitorGlobalMetrics(net *network.Network) {
@@ -510,9 +629,15 @@ func monitorGlobalMetrics(net *network.Network) {
 						panic("message stored more than once per node")
 					}
 					storedMessageMap[messageID] = numNodes + 1
-					storedMessages[messageID] = message
+					if !config.Params.StreamGlobalMetrics {
+						storedMessages[messageID] = message
+					}
 				} else {
 					storedMessageMap[messageID] = 1
+					if config.Params.StreamGlobalMetrics {
+						streamMessageStored(message)
+					}
+					recordBurnPolicyIssuance(message)
 					confirmedMessageMutex.Lock()
 					unconfirmedMessageCounter[message.Issuer] += 1
 					confirmedMessageMutex.Unlock()
@@ -525,9 +650,14 @@ func monitorGlobalMetrics(net *network.Network) {
 					disseminatedMessageMutex.Lock()
 					disseminatedMessageCounter[message.Issuer] += 1
 					undisseminatedMessageCounter[message.Issuer] -= 1
-					disseminatedMessages[messageID] = message
-					//log.Debug("Mana Burn value: ", message.ManaBurnValue)
-					disseminatedMessageMetadata[messageID] = messageMetadata
+					if config.Params.StreamGlobalMetrics {
+						streamMessageDisseminated(message, messageMetadata)
+						delete(storedMessageMap, messageID)
+					} else {
+						disseminatedMessages[messageID] = message
+						//log.Debug("Mana Burn value: ", message.ManaBurnValue)
+						disseminatedMessageMetadata[messageID] = messageMetadata
+					}
 					disseminatedMessageMutex.Unlock()
 				}
 				storedMessageMutex.Unlock()
@@ -551,8 +681,13 @@ func monitorGlobalMetrics(net *network.Network) {
 				if confirmedMessageMap[message.ID] == config.Params.NodesCount {
 					partiallyConfirmedMessageCounter[message.Issuer] -= 1
 					fullyConfirmedMessageCounter[message.Issuer] += 1
-					fullyConfirmedMessages[message.ID] = message
-					fullyConfirmedMessageMetadata[message.ID] = messageMetadata
+					recordBurnPolicyConfirmation(message, messageMetadata)
+					if config.Params.StreamGlobalMetrics {
+						streamMessageConfirmed(message, messageMetadata)
+					} else {
+						fullyConfirmedMessages[message.ID] = message
+						fullyConfirmedMessageMetadata[message.ID] = messageMetadata
+					}
 				}
 
 				// The accepted time difference between the node which first accepted it and the last node which accepted it lastly
@@ -560,14 +695,42 @@ func monitorGlobalMetrics(net *network.Network) {
 				defer confirmedDelayInNetworkMutex.Unlock()
 				if firstAcceptedTime, exists := firstConfirmedTimeMap[message.ID]; exists {
 					if confirmedMessageMap[message.ID] == config.Params.NodesCount {
-						confirmedDelayInNetworkMap[message.ID] = messageMetadata.ConfirmationTime().Sub(firstAcceptedTime)
+						if config.Params.StreamGlobalMetrics {
+							streamAcceptanceLatency(message.ID, messageMetadata.ConfirmationTime().Sub(firstAcceptedTime))
+						} else {
+							confirmedDelayInNetworkMap[message.ID] = messageMetadata.ConfirmationTime().Sub(firstAcceptedTime)
+						}
 						delete(firstConfirmedTimeMap, message.ID)
 					}
 				} else {
 					firstConfirmedTimeMap[message.ID] = messageMetadata.ConfirmationTime()
 				}
-
+				if config.Params.StreamGlobalMetrics && messageDecided(message.ID) {
+					delete(firstConfirmedTimeMap, message.ID)
+				}
 			}))
+		// count the drops of every issuer over all nodes
+		tangle := mbPeer.Node.(multiverse.NodeInterface).Tangle()
+		tangle.Scheduler.Events().MessageDropped.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
+			if message := tangle.Storage.Message(messageID); message != nil {
+				droppedMessageMutex.Lock()
+				droppedMessageCounter[message.Issuer] += 1
+				droppedMessageMutex.Unlock()
+				recordBurnPolicyDrop(message)
+			}
+		}))
+		if config.Params.StreamGlobalMetrics {
+			mbPeer.Node.(multiverse.NodeInterface).Tangle().ApprovalManager.Events.MessageOrphaned.Attach(
+				events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+					confirmedMessageMutex.Lock()
+					defer confirmedMessageMutex.Unlock()
+					if messageDecided(message.ID) {
+						confirmedDelayInNetworkMutex.Lock()
+						delete(firstConfirmedTimeMap, message.ID)
+						confirmedDelayInNetworkMutex.Unlock()
+					}
+				}))
+		}
 	}
 	// define header with time of dump and each node ID
 	gmHeader := make([]string, 0, config.Params.NodesCount+1)
@@ -620,6 +783,42 @@ func monitorGlobalMetrics(net *network.Network) {
 	if err := unconfirmationResultsWriter.Write(gmHeader); err != nil {
 		panic(err)
 	}
+	file, err = createFile(path.Join(config.Params.SchedulerOutputDir, "droppedMessages.csv"))
+	if err != nil {
+		panic(err)
+	}
+	droppedResultsWriter := csv.NewWriter(file)
+	if err := droppedResultsWriter.Write(gmHeader); err != nil {
+		panic(err)
+	}
+	file, err = createFile(path.Join(config.Params.SchedulerOutputDir, "manaDivergence.csv"))
+	if err != nil {
+		panic(err)
+	}
+	manaDivergenceWriter := csv.NewWriter(file)
+	if err := manaDivergenceWriter.Write(gmHeader); err != nil {
+		panic(err)
+	}
+	file, err = createFile(path.Join(config.Params.SchedulerOutputDir, "fairnessIndex.csv"))
+	if err != nil {
+		panic(err)
+	}
+	fairnessWriter := csv.NewWriter(file)
+	if err := fairnessWriter.Write(gmHeader); err != nil {
+		panic(err)
+	}
+	file, err = createFile(path.Join(config.Params.SchedulerOutputDir, "deficitTrajectories.csv"))
+	if err != nil {
+		panic(err)
+	}
+	deficitWriter := csv.NewWriter(file)
+	if err := deficitWriter.Write(append([]string{"Node ID"}, gmHeader...)); err != nil {
+		panic(err)
+	}
+
+	if config.Params.StreamGlobalMetrics {
+		setupGlobalMetricsStreams()
+	}
 
 	go func() {
 		for {
@@ -630,7 +829,11 @@ func monitorGlobalMetrics(net *network.Network) {
 					undissemResultsWriter,
 					confirmationResultsWriter,
 					partialConfirmationResultsWriter,
-					unconfirmationResultsWriter)
+					unconfirmationResultsWriter,
+					droppedResultsWriter)
+				dumpManaDivergence(net, manaDivergenceWriter)
+				recordManaBalances(net)
+				dumpSchedulerAnalytics(net, fairnessWriter, deficitWriter)
 			case <-shutdownGlobalMetrics:
 				log.Warn("Shutting down global metrics")
 				return
@@ -639,7 +842,159 @@ func monitorGlobalMetrics(net *network.Network) {
 	}()
 }
 
+// dumpManaDivergence writes the standard deviation of the mana of every node over the views of all nodes.
+func dumpManaDivergence(net *network.Network, writer *csv.Writer) {
+	record := make([]string, config.Params.NodesCount+1)
+	views := make([]float64, len(net.Peers))
+	for id := 0; id < config.Params.NodesCount; id++ {
+		mean := 0.0
+		for i, peer := range net.Peers {
+			views[i] = peer.Node.(multiverse.NodeInterface).Tangle().ManaManager.GetNodeAccessMana(network.PeerID(id))
+			mean += views[i] / float64(len(views))
+		}
+		variance := 0.0
+		for _, mana := range views {
+			variance += (mana - mean) * (mana - mean) / float64(len(views))
+		}
+		record[id] = strconv.FormatFloat(math.Sqrt(variance), 'f', 6, 64)
+	}
+	record[config.Params.NodesCount] = strconv.FormatInt(time.Since(simulationStartTime).Nanoseconds(), 10)
+	if err := writer.Write(record); err != nil {
+		panic(err)
+	}
+	writer.Flush()
+}
+
+// region streamed global metrics //////////////////////////////////////////////////////////////////////////////////////
+
+// setupGlobalMetricsStreams creates the per-message result files, which are written while the simulation is running
+// instead of at the end of it.
+func setupGlobalMetricsStreams() {
+	streams := map[string][]string{
+		path.Join(config.Params.GeneralOutputDir, "BlockInformation.csv"):                {"Issuer Burn Policy", "Message ID", "Issuance Time Since Start (ns)", "Confirmation Time (ns)"},
+		path.Join(config.Params.SchedulerOutputDir, "DisseminationLatency.csv"):          {"Issuer ID", "Dissemination Time", "Dissemination Latency"},
+		path.Join(config.Params.GeneralOutputDir, "ConfirmationLatency.csv"):             {"Issuer ID", "Confirmation Time", "Confirmation Latency"},
+		path.Join(config.Params.GeneralOutputDir, "acceptanceTimeLatencyAmongNodes.csv"): {"blockID", "Accepted Time Diff"},
+	}
+	for fileName, header := range streams {
+		file, err := createFile(fileName)
+		if err != nil {
+			panic(err)
+		}
+		writer := csv.NewWriter(file)
+		if err := writer.Write(header); err != nil {
+			panic(err)
+		}
+		writer.Flush()
+		streamWriters[path.Base(fileName)] = writer
+	}
+}
+
+func writeStreamRecord(fileName string, record []string) {
+	streamMutex.Lock()
+	defer streamMutex.Unlock()
+	if err := streamWriters[fileName].Write(record); err != nil {
+		panic(err)
+	}
+}
+
+func streamMessageStored(message *multiverse.Message) {
+	streamMutex.Lock()
+	defer streamMutex.Unlock()
+	pendingBlocks[message.ID] = &blockRecord{
+		issuer:       message.Issuer,
+		issuanceTime: message.IssuanceTime,
+	}
+}
+
+func streamMessageDisseminated(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+	writeStreamRecord("DisseminationLatency.csv", []string{
+		strconv.FormatInt(int64(message.Issuer), 10),
+		strconv.FormatInt(int64(messageMetadata.ArrivalTime().Sub(simulationStartTime).Nanoseconds()), 10),
+		strconv.FormatInt(int64(messageMetadata.ArrivalTime().Sub(message.IssuanceTime).Nanoseconds()), 10),
+	})
+}
+
+func streamMessageConfirmed(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+	writeStreamRecord("ConfirmationLatency.csv", []string{
+		strconv.FormatInt(int64(message.Issuer), 10),
+		strconv.FormatInt(int64(messageMetadata.ConfirmationTime().Sub(simulationStartTime).Nanoseconds()), 10),
+		strconv.FormatInt(int64(messageMetadata.ConfirmationTime().Sub(message.IssuanceTime).Nanoseconds()), 10),
+	})
+	streamBlockInformation(message.ID, messageMetadata.ConfirmationTime().Sub(message.IssuanceTime))
+}
+
+func streamAcceptanceLatency(messageID multiverse.MessageID, timeDiff time.Duration) {
+	writeStreamRecord("acceptanceTimeLatencyAmongNodes.csv", []string{
+		strconv.FormatInt(int64(messageID), 10),
+		strconv.FormatInt(timeDiff.Nanoseconds(), 10),
+	})
+}
+
+// streamBlockInformation writes the row of the message to BlockInformation.csv, if it was not written before.
+func streamBlockInformation(messageID multiverse.MessageID, confirmationTime time.Duration) {
+	streamMutex.Lock()
+	defer streamMutex.Unlock()
+	block, exists := pendingBlocks[messageID]
+	if !exists {
+		return
+	}
+	delete(pendingBlocks, messageID)
+	if confirmationTime < 0 {
+		confirmationTime = 0
+	}
+	record := []string{
+		strconv.FormatInt(int64(config.Params.BurnPolicies[int(block.issuer)]), 10),
+		strconv.FormatInt(int64(messageID), 10),
+		strconv.FormatInt(block.issuanceTime.Sub(simulationStartTime).Nanoseconds(), 10),
+		strconv.FormatInt(confirmationTime.Nanoseconds(), 10),
+	}
+	if err := streamWriters["BlockInformation.csv"].Write(record); err != nil {
+		panic(err)
+	}
+}
+
+// messageDecided counts the nodes that confirmed or orphaned the message and drops the message from the global maps
+// once all nodes decided on it. It returns true if the message was decided by all nodes, confirmedMessageMutex has to
+// be held by the caller.
+func messageDecided(messageID multiverse.MessageID) bool {
+	decidedMessageMap[messageID]++
+	if decidedMessageMap[messageID] < config.Params.NodesCount {
+		return false
+	}
+	delete(decidedMessageMap, messageID)
+	delete(confirmedMessageMap, messageID)
+	// messages that were orphaned by some nodes are never fully confirmed
+	streamBlockInformation(messageID, 0)
+	return true
+}
+
+// flushGlobalMetricsStreams writes the messages that were never decided by all nodes and flushes all streams.
+func flushGlobalMetricsStreams() {
+	streamMutex.Lock()
+	pendingMessageIDs := make([]multiverse.MessageID, 0, len(pendingBlocks))
+	for messageID := range pendingBlocks {
+		pendingMessageIDs = append(pendingMessageIDs, messageID)
+	}
+	streamMutex.Unlock()
+	for _, messageID := range pendingMessageIDs {
+		streamBlockInformation(messageID, 0)
+	}
+
+	streamMutex.Lock()
+	defer streamMutex.Unlock()
+	for _, writer := range streamWriters {
+		writer.Flush()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func dumpAcceptanceLatencyAmongNodes() {
+	if config.Params.StreamGlobalMetrics {
+		return
+	}
+
 	// accepted time latency in network
 	file, err := createFile(path.Join(config.Params.GeneralOutputDir, "acceptanceTimeLatencyAmongNodes.csv"))
 	if err != nil {
@@ -721,6 +1076,12 @@ func dumpFinalData(net *network.Network) {
 		writer.Flush()
 	}
 
+	if config.Params.StreamGlobalMetrics {
+		flushGlobalMetricsStreams()
+		dumpLocalMetricsNames()
+		return
+	}
+
 	file, err = createFile(path.Join(config.Params.GeneralOutputDir, "BlockInformation.csv"))
 	if err != nil {
 		panic(err)
@@ -821,11 +1182,15 @@ func dumpFinalData(net *network.Network) {
 		}
 		writer.Flush()
 	}
-	file, err = createFile(path.Join(config.Params.GeneralOutputDir, "localMetrics.csv"))
+	dumpLocalMetricsNames()
+}
+
+func dumpLocalMetricsNames() {
+	file, err := createFile(path.Join(config.Params.GeneralOutputDir, "localMetrics.csv"))
 	if err != nil {
 		panic(err)
 	}
-	writer = csv.NewWriter(file)
+	writer := csv.NewWriter(file)
 	for name := range localMetrics {
 		if err := writer.Write([]string{name}); err != nil {
 			panic(err)
@@ -1197,6 +1562,7 @@ func dumpRecords(dsResultsWriter *csv.Writer, tpResultsWriter *csv.Writer, ccRes
 	aR, aG, aB := getLikesPerRGB(adversaryCounters, "confirmedNodes")
 	hR, hG, hB := r-aR, g-aG, b-aB
 	if Max(Max(hB, hR), hG) >= int64(config.Params.SimulationStopThreshold*float64(honestNodesCount)) {
+		recordBalancingConsensus()
 		shutdownSignal <- types.Void
 	}
 	atomicCounters.Set("tps", 0)
@@ -1327,7 +1693,7 @@ func dumpResultsAD(adResultsWriter *csv.Writer, net *network.Network) {
 	for groupID, group := range net.AdversaryGroups {
 		record := []string{
 			strconv.FormatInt(int64(groupID), 10),
-			network.AdversaryTypeToString(group.AdversaryType),
+			group.Strategy(),
 			strconv.FormatInt(int64(len(group.NodeIDs)), 10),
 			strconv.FormatFloat(float64(group.GroupMana)/float64(config.Params.NodesTotalWeight), 'f', 6, 64),
 			strconv.FormatInt(time.Since(simulationStartTime).Nanoseconds(), 10),
@@ -1356,11 +1722,8 @@ func SimulateDoubleSpent(testNetwork *network.Network) {
 
 			for _, nodeID := range group.NodeIDs {
 				peer := testNetwork.Peer(nodeID)
-				// honest node does not implement adversary behavior interface
-				if group.AdversaryType != network.HonestNode {
-					node := adversary.CastAdversary(peer.Node)
-					node.AssignColor(color)
-				}
+				// the behaviors of the group decide whether the node takes over the color, honest nodes ignore it
+				peer.Node.(multiverse.NodeInterface).AssignColor(color)
 				go sendMessage(peer, color)
 				log.Infof("Peer %d sent double spend msg: %v", peer.ID, color)
 			}
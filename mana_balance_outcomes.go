@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region mana balances ////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	manaBalances      csvRows
+	manaBalancesMutex sync.Mutex
+)
+
+// recordManaBalances adds the mean mana of every node over the views of all nodes to the mana balances.
+func recordManaBalances(net *network.Network) {
+	record := make([]string, config.Params.NodesCount+1)
+	for id := 0; id < config.Params.NodesCount; id++ {
+		mean := 0.0
+		for _, peer := range net.Peers {
+			mean += peer.Node.(multiverse.NodeInterface).Tangle().ManaManager.GetNodeAccessMana(network.PeerID(id)) / float64(len(net.Peers))
+		}
+		record[id] = strconv.FormatFloat(mean, 'f', 6, 64)
+	}
+	record[config.Params.NodesCount] = strconv.FormatInt(time.Since(simulationStartTime).Nanoseconds(), 10)
+
+	manaBalancesMutex.Lock()
+	defer manaBalancesMutex.Unlock()
+	manaBalances = append(manaBalances, record)
+}
+
+// dumpManaBalances writes the mana balances recorded at every global metrics tick, one column per node.
+func dumpManaBalances() {
+	header := make([]string, 0, config.Params.NodesCount+1)
+	for id := 0; id < config.Params.NodesCount; id++ {
+		header = append(header, fmt.Sprintf("Node %d", id))
+	}
+	header = append(header, "ns since start")
+
+	manaBalancesMutex.Lock()
+	defer manaBalancesMutex.Unlock()
+	writeCSV(path.Join(config.Params.SchedulerOutputDir, "manaBalances.csv"), header, manaBalances)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
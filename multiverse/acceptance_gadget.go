@@ -0,0 +1,284 @@
+package multiverse
+
+import (
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region AcceptanceGadget Interface ///////////////////////////////////////////////////////////////////////////////////
+
+// AcceptanceGadget decides when messages become final. All gadgets report their decisions through the events of the
+// ApprovalManager (MessageConfirmed, MessageOrphaned), so the rest of the simulator is independent of the gadget in use.
+type AcceptanceGadget interface {
+	Setup()
+	Name() string
+}
+
+// NewAcceptanceGadget creates the gadget with the given name, falling back to the AW threshold gadget.
+func NewAcceptanceGadget(tangle *Tangle, name string) (gadget AcceptanceGadget) {
+	switch name {
+	case "Depth":
+		gadget = &DepthGadget{
+			tangle: tangle,
+			depths: make(map[MessageID]int),
+		}
+	case "ValidatorK":
+		gadget = &ValidatorKGadget{
+			tangle:    tangle,
+			approvers: make(map[MessageID]map[network.PeerID]struct{}),
+		}
+	case "TwoPhase":
+		gadget = &TwoPhaseGadget{
+			tangle:      tangle,
+			confirmable: make(map[MessageID]struct{}),
+		}
+	default:
+		gadget = &ThresholdGadget{tangle: tangle}
+	}
+	return
+}
+
+// acceptanceGadgetName returns the name of the gadget configured for the node type of the given tangle.
+func acceptanceGadgetName(tangle *Tangle) string {
+	if tangle.WeightDistribution.Weight(tangle.Peer.ID) == 0 && config.Params.NonValidatorAcceptanceGadget != "" {
+		return config.Params.NonValidatorAcceptanceGadget
+	}
+	return config.Params.AcceptanceGadget
+}
+
+// decidePastCone decides the message and all undecided messages in its past cone.
+func decidePastCone(tangle *Tangle, messageID MessageID) {
+	tangle.Utils.WalkMessagesAndMetadata(func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker) {
+		if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+			return
+		}
+		tangle.ApprovalManager.Decide(message, messageMetadata)
+
+		for strongParentID := range message.StrongParents {
+			walker.Push(strongParentID)
+		}
+
+		for weakParentID := range message.WeakParents {
+			walker.Push(weakParentID)
+		}
+	}, NewMessageIDs(messageID), false)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ThresholdGadget //////////////////////////////////////////////////////////////////////////////////////////////
+
+// ThresholdGadget confirms messages once their approval weight reaches ConfirmationThreshold of the total weight.
+type ThresholdGadget struct {
+	tangle *Tangle
+}
+
+func (g *ThresholdGadget) Setup() {
+	g.tangle.ApprovalManager.Events.MessageWeightUpdated.Attach(events.NewClosure(func(message *Message, messageMetadata *MessageMetadata, weight uint64) {
+		if float64(weight) >= config.Params.ConfirmationThreshold*float64(g.tangle.WeightDistribution.TotalWeight()) {
+			g.tangle.ApprovalManager.Decide(message, messageMetadata)
+		}
+	}))
+}
+
+func (g *ThresholdGadget) Name() string {
+	return "Threshold"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DepthGadget //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DepthGadget confirms messages once at least ConfirmationDepth messages are stacked on top of them, regardless of
+// their weight.
+type DepthGadget struct {
+	tangle *Tangle
+	// depths holds the length of the longest chain of messages on top of every undecided message.
+	depths map[MessageID]int
+}
+
+func (g *DepthGadget) Setup() {
+	g.tangle.Solidifier.Events.MessageSolid.Attach(events.NewClosure(g.onMessageSolid))
+	g.tangle.Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs MessageIDs) {
+		for messageID := range messageIDs {
+			delete(g.depths, messageID)
+		}
+	}))
+}
+
+func (g *DepthGadget) Name() string {
+	return "Depth"
+}
+
+func (g *DepthGadget) onMessageSolid(messageID MessageID) {
+	// the depths only ever grow, so the new depth is only propagated to the parents whose depth it raises, which
+	// bounds the work per message by ConfirmationDepth instead of the size of its past cone
+	type messageDepth struct {
+		messageID MessageID
+		depth     int
+	}
+	stack := []messageDepth{{messageID, 0}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		messageMetadata := g.tangle.Storage.MessageMetadata(current.messageID)
+		if messageMetadata == nil {
+			continue
+		}
+		if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+			delete(g.depths, current.messageID)
+			continue
+		}
+		if depth, exists := g.depths[current.messageID]; exists && depth >= current.depth {
+			continue
+		}
+		if current.depth >= config.Params.ConfirmationDepth {
+			delete(g.depths, current.messageID)
+			decidePastCone(g.tangle, current.messageID)
+			continue
+		}
+		g.depths[current.messageID] = current.depth
+
+		message := g.tangle.Storage.Message(current.messageID)
+		for strongParentID := range message.StrongParents {
+			stack = append(stack, messageDepth{strongParentID, current.depth + 1})
+		}
+		for weakParentID := range message.WeakParents {
+			stack = append(stack, messageDepth{weakParentID, current.depth + 1})
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ValidatorKGadget /////////////////////////////////////////////////////////////////////////////////////////////
+
+// ValidatorKGadget only counts validation blocks and confirms messages once ValidatorConfirmations distinct validators
+// have them in the past cone of one of their validation blocks.
+type ValidatorKGadget struct {
+	tangle *Tangle
+	// approvers holds the validators that approve every undecided message.
+	approvers map[MessageID]map[network.PeerID]struct{}
+}
+
+func (g *ValidatorKGadget) Setup() {
+	g.tangle.Solidifier.Events.MessageSolid.Attach(events.NewClosure(g.onMessageSolid))
+	g.tangle.Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs MessageIDs) {
+		for messageID := range messageIDs {
+			delete(g.approvers, messageID)
+		}
+	}))
+}
+
+func (g *ValidatorKGadget) Name() string {
+	return "ValidatorK"
+}
+
+func (g *ValidatorKGadget) onMessageSolid(messageID MessageID) {
+	validationBlock := g.tangle.Storage.Message(messageID)
+	if !validationBlock.Validation {
+		return
+	}
+
+	// the walk stops at messages the validator already approves, as their past cone has been visited by one of its
+	// earlier validation blocks, so every validator only visits every message once
+	g.tangle.Utils.WalkMessagesAndMetadata(func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker) {
+		if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+			delete(g.approvers, message.ID)
+			return
+		}
+
+		approvers, exists := g.approvers[message.ID]
+		if !exists {
+			approvers = make(map[network.PeerID]struct{})
+			g.approvers[message.ID] = approvers
+		}
+		if _, approved := approvers[validationBlock.Issuer]; approved {
+			return
+		}
+
+		approvers[validationBlock.Issuer] = struct{}{}
+		if len(approvers) >= config.Params.ValidatorConfirmations {
+			delete(g.approvers, message.ID)
+			g.tangle.ApprovalManager.Decide(message, messageMetadata)
+		}
+
+		for strongParentID := range message.StrongParents {
+			walker.Push(strongParentID)
+		}
+
+		for weakParentID := range message.WeakParents {
+			walker.Push(weakParentID)
+		}
+	}, NewMessageIDs(messageID), false)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TwoPhaseGadget ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// TwoPhaseGadget accepts messages once their approval weight reaches AcceptanceThreshold and confirms accepted messages
+// once they are in the past cone of an accepted validation block.
+type TwoPhaseGadget struct {
+	tangle *Tangle
+	// confirmable holds the messages in the past cone of an accepted validation block that are not accepted yet.
+	confirmable map[MessageID]struct{}
+}
+
+func (g *TwoPhaseGadget) Setup() {
+	g.tangle.ApprovalManager.Events.MessageWeightUpdated.Attach(events.NewClosure(func(message *Message, messageMetadata *MessageMetadata, weight uint64) {
+		if float64(weight) >= config.Params.AcceptanceThreshold*float64(g.tangle.WeightDistribution.TotalWeight()) {
+			g.tangle.ApprovalManager.Accept(message, messageMetadata)
+		}
+	}))
+	g.tangle.ApprovalManager.Events.MessageAccepted.Attach(events.NewClosure(g.onMessageAccepted))
+	g.tangle.Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs MessageIDs) {
+		for messageID := range messageIDs {
+			delete(g.confirmable, messageID)
+		}
+	}))
+}
+
+func (g *TwoPhaseGadget) Name() string {
+	return "TwoPhase"
+}
+
+func (g *TwoPhaseGadget) onMessageAccepted(message *Message, messageMetadata *MessageMetadata, weight uint64, messageIDCounter int64) {
+	if _, exists := g.confirmable[message.ID]; exists {
+		delete(g.confirmable, message.ID)
+		g.tangle.ApprovalManager.Decide(message, messageMetadata)
+	}
+
+	if !message.Validation {
+		return
+	}
+
+	// the weight is propagated from the validation block to its past cone, so the parents of an accepted validation
+	// block might only become accepted afterwards and are marked as confirmable until then
+	g.tangle.Utils.WalkMessagesAndMetadata(func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker) {
+		if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+			return
+		}
+
+		if messageMetadata.Accepted() {
+			g.tangle.ApprovalManager.Decide(message, messageMetadata)
+		} else if _, exists := g.confirmable[message.ID]; exists {
+			return
+		} else {
+			g.confirmable[message.ID] = struct{}{}
+		}
+
+		for strongParentID := range message.StrongParents {
+			walker.Push(strongParentID)
+		}
+
+		for weakParentID := range message.WeakParents {
+			walker.Push(weakParentID)
+		}
+	}, NewMessageIDs(message.ID), false)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,128 @@
+package multiverse
+
+import (
+	"testing"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// withGadget configures the acceptance gadget used by the tangles created in the test.
+func withGadget(t *testing.T, name string) {
+	previousName := config.Params.AcceptanceGadget
+	t.Cleanup(func() { config.Params.AcceptanceGadget = previousName })
+	config.Params.AcceptanceGadget = name
+}
+
+func TestThresholdGadget(t *testing.T) {
+	withGadget(t, "Threshold")
+	tangle := newTestTangle(t, 4)
+
+	message := newTestMessage(1, false)
+	tangle.ProcessMessage(message)
+	for validator := 0; validator < 3; validator++ {
+		if tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+			t.Fatalf("message confirmed with the weight of %d out of 4 validators", validator)
+		}
+		tangle.ProcessMessage(newTestMessage(network.PeerID(validator), true, message.ID))
+	}
+	if !tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatal("message not confirmed with the weight of 3 out of 4 validators")
+	}
+}
+
+func TestDepthGadget(t *testing.T) {
+	withGadget(t, "Depth")
+	defer func(depth int) { config.Params.ConfirmationDepth = depth }(config.Params.ConfirmationDepth)
+	config.Params.ConfirmationDepth = 3
+	tangle := newTestTangle(t, 4)
+
+	message := newTestMessage(1, false)
+	tangle.ProcessMessage(message)
+	// a second branch of the same length must not add up with the first one
+	side := newTestMessage(2, false, message.ID)
+	tangle.ProcessMessage(side)
+
+	tip := message.ID
+	for depth := 0; depth < config.Params.ConfirmationDepth; depth++ {
+		if tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+			t.Fatalf("message confirmed with %d messages on top", depth)
+		}
+		next := newTestMessage(1, false, tip)
+		tangle.ProcessMessage(next)
+		tip = next.ID
+	}
+	if !tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatalf("message not confirmed with %d messages on top", config.Params.ConfirmationDepth)
+	}
+	if tangle.Storage.MessageMetadata(side.ID).Confirmed() {
+		t.Fatal("message with a single message on top confirmed")
+	}
+}
+
+func TestValidatorKGadget(t *testing.T) {
+	withGadget(t, "ValidatorK")
+	defer func(confirmations int) { config.Params.ValidatorConfirmations = confirmations }(config.Params.ValidatorConfirmations)
+	config.Params.ValidatorConfirmations = 3
+	tangle := newTestTangle(t, 4)
+
+	message := newTestMessage(1, false)
+	tangle.ProcessMessage(message)
+
+	// validation blocks of the same validator only count once
+	tip := message.ID
+	for i := 0; i < config.Params.ValidatorConfirmations; i++ {
+		validationBlock := newTestMessage(0, true, tip)
+		tangle.ProcessMessage(validationBlock)
+		tip = validationBlock.ID
+	}
+	if tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatal("message confirmed by the validation blocks of a single validator")
+	}
+
+	tangle.ProcessMessage(newTestMessage(1, true, tip))
+	if tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatal("message confirmed by 2 validators")
+	}
+	tangle.ProcessMessage(newTestMessage(2, true, message.ID))
+	if !tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatal("message not confirmed by 3 validators")
+	}
+}
+
+func TestTwoPhaseGadget(t *testing.T) {
+	withGadget(t, "TwoPhase")
+	defer func(threshold float64) { config.Params.AcceptanceThreshold = threshold }(config.Params.AcceptanceThreshold)
+	config.Params.AcceptanceThreshold = 0.5
+	tangle := newTestTangle(t, 4)
+
+	message := newTestMessage(1, false)
+	tangle.ProcessMessage(message)
+	first := newTestMessage(0, true, message.ID)
+	tangle.ProcessMessage(first)
+	if tangle.Storage.MessageMetadata(message.ID).Accepted() {
+		t.Fatal("message accepted with the weight of 1 out of 4 validators")
+	}
+
+	// the first phase accepts the message at the threshold, but no accepted validation block approves it yet
+	second := newTestMessage(1, true, message.ID)
+	tangle.ProcessMessage(second)
+	if !tangle.Storage.MessageMetadata(message.ID).Accepted() {
+		t.Fatal("message not accepted with the weight of 2 out of 4 validators")
+	}
+	if tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatal("message confirmed without an accepted validation block approving it")
+	}
+
+	// the second phase confirms the message once a validation block approving it is accepted
+	tangle.ProcessMessage(newTestMessage(2, true, second.ID))
+	if !tangle.Storage.MessageMetadata(second.ID).Accepted() {
+		t.Fatal("validation block not accepted with the weight of 2 out of 4 validators")
+	}
+	if !tangle.Storage.MessageMetadata(message.ID).Confirmed() {
+		t.Fatal("message not confirmed by an accepted validation block")
+	}
+	if tangle.Storage.MessageMetadata(first.ID).Confirmed() {
+		t.Fatal("validation block confirmed without being accepted")
+	}
+}
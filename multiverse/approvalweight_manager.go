@@ -1,11 +1,13 @@
 package multiverse
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/iotaledger/hive.go/datastructure/walker"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
 )
 
 // region ApprovalManager ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -13,16 +15,25 @@ import (
 type ApprovalManager struct {
 	tangle *Tangle
 	Events *ApprovalWeightEvents
+
+	// validatorIndex maps the issuers of validation blocks to their bit in the approvers of the messages, in the order
+	// in which the node sees their first validation block.
+	validatorIndex map[network.PeerID]int
+	// processingTime is the cumulative time (ns) spent propagating approval weight, used to benchmark the engines.
+	processingTime int64
 }
 
 func NewApprovalManager(tangle *Tangle) *ApprovalManager {
 	return &ApprovalManager{
 		tangle: tangle,
 		Events: &ApprovalWeightEvents{
+			MessageAccepted:             events.NewEvent(approvalEventCaller),
 			MessageConfirmed:            events.NewEvent(approvalEventCaller),
-			MessageWeightUpdated:        events.NewEvent(approvalEventCaller),
+			MessageOrphaned:             events.NewEvent(approvalEventCaller),
+			MessageWeightUpdated:        events.NewEvent(weightEventCaller),
 			MessageWitnessWeightUpdated: events.NewEvent(witnessWeightEventCaller),
 		},
+		validatorIndex: make(map[network.PeerID]int),
 	}
 }
 
@@ -30,47 +41,62 @@ func approvalEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*Message, *MessageMetadata, uint64, int64))(params[0].(*Message), params[1].(*MessageMetadata), params[2].(uint64), params[3].(int64))
 }
 
+func weightEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Message, *MessageMetadata, uint64))(params[0].(*Message), params[1].(*MessageMetadata), params[2].(uint64))
+}
+
 func witnessWeightEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*Message, uint64))(params[0].(*Message), params[1].(uint64))
 }
 
 func (a *ApprovalManager) Setup() {
 	a.tangle.Solidifier.Events.MessageSolid.Attach(events.NewClosure(a.ApproveMessages))
+	a.tangle.Storage.Events.MessageExpired.Attach(events.NewClosure(func(_ MessageID, message *Message, messageMetadata *MessageMetadata) {
+		a.Orphan(message, messageMetadata)
+	}))
 }
 
-func (a *ApprovalManager) ApproveMessages(messageID MessageID) {
+// ProcessingTime returns the cumulative time spent in the approval weight propagation.
+func (a *ApprovalManager) ProcessingTime() time.Duration {
+	return time.Duration(atomic.LoadInt64(&a.processingTime))
+}
 
+func (a *ApprovalManager) ApproveMessages(messageID MessageID) {
 	issuingMessage := a.tangle.Storage.Message(messageID)
-	byteIndex := issuingMessage.Issuer / 8
-	mod := issuingMessage.Issuer % 8
-
 	if !issuingMessage.Validation {
 		return
 	}
+	// ApproveMessages runs when the block gets solid, before it is booked and reaches OpinionManager.UpdateWeights, so
+	// the block is tracked here to detect an equivocation on first sight of its second block, before that block adds
+	// weight. Tracking the same block twice has no effect.
+	a.tangle.EquivocationDetector.Track(issuingMessage)
+	if a.tangle.EquivocationDetector.Ignored(issuingMessage.Issuer) {
+		return
+	}
+
+	start := time.Now()
+	defer func() {
+		atomic.AddInt64(&a.processingTime, int64(time.Since(start)))
+	}()
+
+	switch config.Params.ApprovalWeightEngine {
+	case "Indexed":
+		a.approveIndexed(issuingMessage)
+	default:
+		a.approveByWalk(issuingMessage)
+	}
+}
+
+// approveByWalk walks the whole past cone of the validation block that its issuer did not approve yet and marks it as
+// an approver of the messages.
+func (a *ApprovalManager) approveByWalk(issuingMessage *Message) {
+	index := a.validatorBitIndex(issuingMessage.Issuer)
 
 	weight := a.tangle.WeightDistribution.Weight(issuingMessage.Issuer)
 	a.tangle.Utils.WalkMessagesAndMetadata(func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker) {
-		if int(a.tangle.Peer.ID) == config.Params.MonitoredWitnessWeightPeer && messageMetadata.ID() == MessageID(config.Params.MonitoredWitnessWeightMessageID) {
-			// log.Infof("Peer %d Message %d Witness Weight %d", a.tangle.Peer.ID, messageMetadata.id, messageMetadata.weight)
-			a.Events.MessageWitnessWeightUpdated.Trigger(message, messageMetadata.Weight())
-		}
-		weightByte := messageMetadata.WeightByte(int(byteIndex))
-		if weightByte&(1<<mod) == 0 {
-			weightByte |= 1 << mod
-			messageMetadata.SetWeightByte(int(byteIndex), weightByte)
-			messageMetadata.AddWeight(weight)
-			a.Events.MessageWeightUpdated.Trigger(message, messageMetadata, messageMetadata.Weight())
-			if float64(messageMetadata.Weight()) >= config.Params.ConfirmationThreshold*float64(a.tangle.WeightDistribution.TotalWeight()) &&
-				!messageMetadata.Confirmed() && !messageMetadata.Orphaned() {
-				// check if this should be orphaned
-				now := time.Now()
-				if a.tangle.Storage.TooOld(message) {
-					messageMetadata.SetOrphanTime(now)
-				} else {
-					messageMetadata.SetConfirmationTime(now)
-					a.Events.MessageConfirmed.Trigger(message, messageMetadata, messageMetadata.Weight(), messageIDCounter)
-				}
-			}
+		a.monitorWitnessWeight(message, messageMetadata)
+		if messageMetadata.AddApprover(index) {
+			a.addWeight(message, messageMetadata, weight)
 
 			for strongParentID := range message.StrongParents {
 				walker.Push(strongParentID)
@@ -80,14 +106,115 @@ func (a *ApprovalManager) ApproveMessages(messageID MessageID) {
 				walker.Push(weakParentID)
 			}
 		}
+	}, NewMessageIDs(issuingMessage.ID), false)
+}
+
+// approveIndexed propagates the weight of the validation block like approveByWalk, but also stops at messages that are
+// already confirmed or orphaned, as their past cone has already been decided. Decided messages therefore keep the
+// weight they had when they were decided. Messages that have already been approved by the same validator are skipped,
+// so every validator only walks the part of the past cone that it did not support yet. The approval bits are the
+// latest support of every validator, markers or per-issuer sequence numbers are not used, as the messages of an issuer
+// do not form a chain and approving one of them says nothing about the ones before it.
+func (a *ApprovalManager) approveIndexed(issuingMessage *Message) {
+	index := a.validatorBitIndex(issuingMessage.Issuer)
+
+	weight := a.tangle.WeightDistribution.Weight(issuingMessage.Issuer)
+	a.tangle.Utils.WalkMessagesAndMetadata(func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker) {
+		if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+			return
+		}
+
+		if messageMetadata.ApprovedBy(index) {
+			return
+		}
+
+		a.monitorWitnessWeight(message, messageMetadata)
+		messageMetadata.AddApprover(index)
+		a.addWeight(message, messageMetadata, weight)
+
+		// the approvers are not needed anymore once the message is decided
+		if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+			messageMetadata.ReleaseApprovers()
+		}
+
+		for strongParentID := range message.StrongParents {
+			walker.Push(strongParentID)
+		}
 
-	}, NewMessageIDs(messageID), false)
+		for weakParentID := range message.WeakParents {
+			walker.Push(weakParentID)
+		}
+	}, NewMessageIDs(issuingMessage.ID), false)
+}
+
+// validatorBitIndex returns the bit of the given validator in the approvers of the messages, assigning a new one if
+// the validator has not issued a validation block before.
+func (a *ApprovalManager) validatorBitIndex(peerID network.PeerID) int {
+	index, exists := a.validatorIndex[peerID]
+	if !exists {
+		index = len(a.validatorIndex)
+		a.validatorIndex[peerID] = index
+	}
+	return index
 }
 
+func (a *ApprovalManager) monitorWitnessWeight(message *Message, messageMetadata *MessageMetadata) {
+	if int(a.tangle.Peer.ID) == config.Params.MonitoredWitnessWeightPeer && messageMetadata.ID() == MessageID(config.Params.MonitoredWitnessWeightMessageID) {
+		// log.Infof("Peer %d Message %d Witness Weight %d", a.tangle.Peer.ID, messageMetadata.id, messageMetadata.weight)
+		a.Events.MessageWitnessWeightUpdated.Trigger(message, messageMetadata.Weight())
+	}
+}
+
+// addWeight adds the weight to the message and notifies the acceptance gadget about the new weight.
+func (a *ApprovalManager) addWeight(message *Message, messageMetadata *MessageMetadata, weight uint64) {
+	messageMetadata.AddWeight(weight)
+	a.Events.MessageWeightUpdated.Trigger(message, messageMetadata, messageMetadata.Weight())
+}
+
+// Decide confirms the message, or orphans it if the OrphanageRule says so. Messages that are already decided are
+// ignored, so all gadgets report every message exactly once.
+func (a *ApprovalManager) Decide(message *Message, messageMetadata *MessageMetadata) {
+	if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+		return
+	}
+
+	if a.tangle.Storage.Orphanable(message) {
+		a.Orphan(message, messageMetadata)
+		return
+	}
+
+	messageMetadata.SetConfirmationTime(time.Now())
+	a.Events.MessageConfirmed.Trigger(message, messageMetadata, messageMetadata.Weight(), messageIDCounter)
+}
+
+// Orphan marks the undecided message as orphaned, it is used for messages that are pruned before they were decided.
+func (a *ApprovalManager) Orphan(message *Message, messageMetadata *MessageMetadata) {
+	if messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+		return
+	}
+
+	messageMetadata.SetOrphanTime(time.Now())
+	a.Events.MessageOrphaned.Trigger(message, messageMetadata, messageMetadata.Weight(), messageIDCounter)
+}
+
+// Accept marks the message as accepted, which is the first phase of gadgets that separate acceptance and confirmation.
+func (a *ApprovalManager) Accept(message *Message, messageMetadata *MessageMetadata) {
+	if messageMetadata.Accepted() || messageMetadata.Confirmed() || messageMetadata.Orphaned() {
+		return
+	}
+
+	messageMetadata.SetAcceptanceTime(time.Now())
+	a.Events.MessageAccepted.Trigger(message, messageMetadata, messageMetadata.Weight(), messageIDCounter)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region ApprovalWeightEvents /////////////////////////////////////////////////////////////////////////////////////////////
 
 type ApprovalWeightEvents struct {
+	MessageAccepted             *events.Event
 	MessageConfirmed            *events.Event
+	MessageOrphaned             *events.Event
 	MessageWeightUpdated        *events.Event
 	MessageWitnessWeightUpdated *events.Event
 }
@@ -0,0 +1,111 @@
+package multiverse
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+const (
+	benchmarkValidatorCount = 1000
+	benchmarkRounds         = 5
+	benchmarkParentCount    = 2
+	benchmarkTipWindow      = 8
+)
+
+// BenchmarkApprovalWeight compares the approval weight engines on a tangle in which every validator issues
+// benchmarkRounds validation blocks that approve some of the most recent blocks.
+func BenchmarkApprovalWeight(b *testing.B) {
+	for _, engine := range []string{"Walk", "Indexed"} {
+		b.Run(engine, func(b *testing.B) {
+			defer func(engine string) { config.Params.ApprovalWeightEngine = engine }(config.Params.ApprovalWeightEngine)
+			config.Params.ApprovalWeightEngine = engine
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tangle := newTestTangle(b, benchmarkValidatorCount)
+				messages := benchmarkValidationBlocks(benchmarkValidatorCount, benchmarkRounds)
+				b.StartTimer()
+
+				for _, message := range messages {
+					tangle.ProcessMessage(message)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkValidationBlocks creates the validation blocks of the given number of rounds, each approving
+// benchmarkParentCount random blocks out of the last benchmarkTipWindow ones.
+func benchmarkValidationBlocks(validatorCount, rounds int) (messages []*Message) {
+	random := rand.New(rand.NewSource(0))
+	for round := 0; round < rounds; round++ {
+		for validator := 0; validator < validatorCount; validator++ {
+			parents := []MessageID{Genesis}
+			if len(messages) > 0 {
+				parents = parents[:0]
+				window := messages
+				if len(window) > benchmarkTipWindow {
+					window = window[len(window)-benchmarkTipWindow:]
+				}
+				for j := 0; j < benchmarkParentCount; j++ {
+					parents = append(parents, window[random.Intn(len(window))].ID)
+				}
+			}
+			messages = append(messages, newTestMessage(network.PeerID(validator), true, parents...))
+		}
+	}
+	return
+}
+
+func TestApprovalWeightValidatorBits(t *testing.T) {
+	for _, engine := range []string{"Walk", "Indexed"} {
+		t.Run(engine, func(t *testing.T) {
+			defer func(engine string) { config.Params.ApprovalWeightEngine = engine }(config.Params.ApprovalWeightEngine)
+			config.Params.ApprovalWeightEngine = engine
+			tangle := newTestTangle(t, 200)
+
+			message := newTestMessage(1, false)
+			tangle.ProcessMessage(message)
+			for _, validator := range []network.PeerID{150, 199, 150} {
+				tangle.ProcessMessage(newTestMessage(validator, true, message.ID))
+			}
+
+			messageMetadata := tangle.Storage.MessageMetadata(message.ID)
+			if messageMetadata.Weight() != 2 {
+				t.Fatalf("expected the weight of 2 validators, got %d", messageMetadata.Weight())
+			}
+			// the bits are indexed by the validators that issued validation blocks, not by the node IDs
+			if len(messageMetadata.approvers) != 1 {
+				t.Fatalf("expected the approvers of 2 validators to fit into one word, got %d", len(messageMetadata.approvers))
+			}
+		})
+	}
+}
+
+func TestApprovalWeightAfterDecision(t *testing.T) {
+	for engine, expectedWeight := range map[string]uint64{"Walk": 4, "Indexed": 3} {
+		t.Run(engine, func(t *testing.T) {
+			defer func(engine string) { config.Params.ApprovalWeightEngine = engine }(config.Params.ApprovalWeightEngine)
+			config.Params.ApprovalWeightEngine = engine
+			tangle := newTestTangle(t, 4)
+
+			message := newTestMessage(1, false)
+			tangle.ProcessMessage(message)
+			for validator := 0; validator < 4; validator++ {
+				tangle.ProcessMessage(newTestMessage(network.PeerID(validator), true, message.ID))
+			}
+
+			// the message is confirmed by 3 validators, only the Walk engine adds the weight of the 4th one
+			messageMetadata := tangle.Storage.MessageMetadata(message.ID)
+			if !messageMetadata.Confirmed() {
+				t.Fatal("message not confirmed")
+			}
+			if messageMetadata.Weight() != expectedWeight {
+				t.Fatalf("expected a weight of %d, got %d", expectedWeight, messageMetadata.Weight())
+			}
+		})
+	}
+}
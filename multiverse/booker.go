@@ -65,7 +65,7 @@ func (b *Booker) inheritColor(message *Message) (inheritedColor Color, err error
 
 func (b *Booker) colorsOfStrongParents(message *Message) (colorsOfStrongParents []Color) {
 	for strongParent := range message.StrongParents {
-		if strongParent == Genesis {
+		if strongParent == Genesis || b.tangle.Storage.IsPruned(strongParent) {
 			continue
 		}
 
@@ -77,7 +77,7 @@ func (b *Booker) colorsOfStrongParents(message *Message) (colorsOfStrongParents
 
 func (b *Booker) colorsOfWeakParents(message *Message) (colorsOfStrongParents []Color) {
 	for weakParent := range message.WeakParents {
-		if weakParent == Genesis {
+		if weakParent == Genesis || b.tangle.Storage.IsPruned(weakParent) {
 			continue
 		}
 
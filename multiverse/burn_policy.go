@@ -0,0 +1,181 @@
+package multiverse
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+// region BurnPolicyType ///////////////////////////////////////////////////////////////////////////////////////////////
+
+type BurnPolicyType int
+
+const (
+	NoBurn   BurnPolicyType = 0
+	Anxious  BurnPolicyType = 1
+	Greedy1  BurnPolicyType = 2
+	Greedy10 BurnPolicyType = 3
+	// FeeEstimation burns the BurnFeePercentile of the burns of the last BurnHistorySize scheduled messages.
+	FeeEstimation BurnPolicyType = 4
+	// Deadline raises the burn from the highest burn in the scheduler to the whole mana of the node the longer no own
+	// message was scheduled, reaching it after BurnDeadline.
+	Deadline BurnPolicyType = 5
+	// RandomGreedy outbids the highest burn in the scheduler by a random amount up to BurnRandomGreedyMax.
+	RandomGreedy BurnPolicyType = 6
+	// Budget bids like Greedy1 but burns at most BurnBudget mana within every BurnBudgetWindow.
+	Budget BurnPolicyType = 7
+)
+
+var burnPolicyNames = map[BurnPolicyType]string{
+	NoBurn:        "NoBurn",
+	Anxious:       "Anxious",
+	Greedy1:       "Greedy1",
+	Greedy10:      "Greedy10",
+	FeeEstimation: "FeeEstimation",
+	Deadline:      "Deadline",
+	RandomGreedy:  "RandomGreedy",
+	Budget:        "Budget",
+}
+
+func (b BurnPolicyType) String() string {
+	if name, exists := burnPolicyNames[b]; exists {
+		return name
+	}
+	return "Unknown"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BurnBidder ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BurnBidder decides how much mana the own messages burn according to the burn policy of the node. It keeps the state
+// of the policies that depend on the history of the local scheduler.
+type BurnBidder struct {
+	tangle *Tangle
+
+	// recentBurns are the burns of the last BurnHistorySize messages scheduled by the local scheduler
+	recentBurns      []float64
+	lastOwnScheduled time.Time
+	// spendings are the burns of the own stored messages within the last BurnBudgetWindow
+	spendings []burnSpending
+
+	mutex sync.Mutex
+}
+
+type burnSpending struct {
+	time time.Time
+	burn float64
+}
+
+func NewBurnBidder(tangle *Tangle) *BurnBidder {
+	return &BurnBidder{
+		tangle: tangle,
+	}
+}
+
+func (b *BurnBidder) Setup() {
+	b.lastOwnScheduled = time.Now()
+	b.tangle.Scheduler.Events().MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		message := b.tangle.Storage.Message(messageID)
+		if message == nil {
+			return
+		}
+
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		b.recentBurns = append(b.recentBurns, message.ManaBurnValue)
+		if len(b.recentBurns) > config.Params.BurnHistorySize {
+			b.recentBurns = b.recentBurns[len(b.recentBurns)-config.Params.BurnHistorySize:]
+		}
+		if message.Issuer == b.tangle.Peer.ID {
+			b.lastOwnScheduled = time.Now()
+		}
+	}))
+	// the budget is only spent once the own message was created and stored, bids that are not used cost nothing
+	b.tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID MessageID, message *Message, messageMetadata *MessageMetadata) {
+		if message.Issuer != b.tangle.Peer.ID || BurnPolicyType(config.Params.BurnPolicies[message.Issuer]) != Budget {
+			return
+		}
+
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		b.spendings = append(b.spendings, burnSpending{time: message.IssuanceTime, burn: message.ManaBurnValue})
+	}))
+}
+
+// BurnValue returns the burn of a new own message and whether the node has enough mana to issue it.
+func (b *BurnBidder) BurnValue(manaManager ManaManager) (burn float64, ok bool) {
+	mana := manaManager.GetNodeAccessMana(b.tangle.Peer.ID)
+	switch policy := BurnPolicyType(config.Params.BurnPolicies[b.tangle.Peer.ID]); policy {
+	case NoBurn:
+		return 0.0, true
+	case Anxious:
+		return mana, true
+	case Greedy1:
+		burn = b.tangle.Scheduler.GetMaxManaBurn() + 1.0
+	case Greedy10:
+		burn = b.tangle.Scheduler.GetMaxManaBurn() + 10.0
+	case FeeEstimation:
+		burn = b.feeEstimate()
+	case Deadline:
+		burn = b.deadlineBurn(mana)
+	case RandomGreedy:
+		burn = b.tangle.Scheduler.GetMaxManaBurn() + rand.Float64()*config.Params.BurnRandomGreedyMax
+	case Budget:
+		return b.budgetBurn(mana)
+	default:
+		panic("invalid burn policy " + policy.String())
+	}
+	return burn, burn <= mana
+}
+
+// feeEstimate returns the BurnFeePercentile of the recently scheduled burns.
+func (b *BurnBidder) feeEstimate() float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if len(b.recentBurns) == 0 {
+		return 0.0
+	}
+	burns := append([]float64{}, b.recentBurns...)
+	sort.Float64s(burns)
+	index := int(math.Ceil(config.Params.BurnFeePercentile*float64(len(burns)))) - 1
+	return burns[int(math.Min(math.Max(float64(index), 0), float64(len(burns)-1)))]
+}
+
+// deadlineBurn interpolates between the highest burn in the scheduler and the whole mana of the node by the time that
+// passed since the last own message was scheduled relative to BurnDeadline.
+func (b *BurnBidder) deadlineBurn(mana float64) float64 {
+	b.mutex.Lock()
+	waited := time.Since(b.lastOwnScheduled)
+	b.mutex.Unlock()
+
+	price := b.tangle.Scheduler.GetMaxManaBurn()
+	urgency := math.Min(float64(waited)/float64(config.Params.BurnDeadline*time.Duration(config.Params.SlowdownFactor)), 1)
+	return price + urgency*math.Max(mana-price, 0)
+}
+
+// budgetBurn bids like Greedy1 as long as the burns of the own messages within BurnBudgetWindow stay within BurnBudget.
+func (b *BurnBidder) budgetBurn(mana float64) (burn float64, ok bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	window := config.Params.BurnBudgetWindow * time.Duration(config.Params.SlowdownFactor)
+	spent := 0.0
+	for len(b.spendings) > 0 && now.Sub(b.spendings[0].time) > window {
+		b.spendings = b.spendings[1:]
+	}
+	for _, spending := range b.spendings {
+		spent += spending.burn
+	}
+
+	burn = b.tangle.Scheduler.GetMaxManaBurn() + 1.0
+	return burn, burn <= mana && spent+burn <= config.Params.BurnBudget
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,45 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+func TestBudgetBurn(t *testing.T) {
+	defer func(policies []int, budget float64, window time.Duration) {
+		config.Params.BurnPolicies, config.Params.BurnBudget, config.Params.BurnBudgetWindow = policies, budget, window
+	}(config.Params.BurnPolicies, config.Params.BurnBudget, config.Params.BurnBudgetWindow)
+	config.Params.BurnPolicies, config.Params.BurnBudgetWindow = []int{int(Budget), int(Budget)}, time.Hour
+
+	tangle := newTestTangle(t, 2)
+	bidder := NewBurnBidder(tangle)
+	bidder.Setup()
+
+	// messages of other nodes do not spend the budget
+	other := newTestMessage(1, false)
+	other.ManaBurnValue = 10
+	tangle.Storage.Store(other)
+	tangle.Storage.Events.MessageStored.Trigger(other.ID, other, tangle.Storage.MessageMetadata(other.ID))
+	burn, _ := bidder.budgetBurn(100)
+	config.Params.BurnBudget = 1.5 * burn
+
+	// bids of messages that are never created do not spend the budget either
+	for i := 0; i < 3; i++ {
+		if _, ok := bidder.budgetBurn(100); !ok {
+			t.Fatalf("bid %d failed although nothing was spent", i)
+		}
+	}
+
+	own := newTestMessage(0, false)
+	own.ManaBurnValue = burn
+	tangle.Storage.Store(own)
+	tangle.Storage.Events.MessageStored.Trigger(own.ID, own, tangle.Storage.MessageMetadata(own.ID))
+	if _, ok := bidder.budgetBurn(100); ok {
+		t.Fatal("bid exceeds the budget after the own message was stored")
+	}
+	if _, ok := bidder.budgetBurn(0.5 * burn); ok {
+		t.Fatal("bid exceeds the mana of the node")
+	}
+}
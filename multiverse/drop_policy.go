@@ -0,0 +1,118 @@
+package multiverse
+
+import (
+	"math/rand"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region DropPolicy ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// DropHead drops the oldest message of the buffer.
+	DropHead = "Head"
+	// DropTail drops the newest message of the buffer.
+	DropTail = "Tail"
+	// DropHeadFromLongest drops the oldest message of the issuer with the largest load.
+	DropHeadFromLongest = "HeadFromLongest"
+	// DropNewestFromLongest drops the newest message of the issuer with the largest load.
+	DropNewestFromLongest = "NewestFromLongest"
+	// DropByBurn drops the message with the lowest mana burn.
+	DropByBurn = "Burn"
+	// DropRandomEarly drops arriving messages with a probability that grows with the buffer load and falls back to the
+	// default policy of the scheduler once the buffer is full.
+	DropRandomEarly = "RED"
+)
+
+// bufferedMessage is a message in the buffer of a scheduler together with the load of its issuer, which is the work
+// relative to the quantum for ICCA+ and the work of its buffered messages for ManaBurn.
+type bufferedMessage struct {
+	message    *Message
+	issuerLoad float64
+}
+
+// dropPolicy returns the policy used to select the dropped message once the buffer is full.
+func dropPolicy(defaultPolicy string) string {
+	if config.Params.DropPolicy == "" || config.Params.DropPolicy == DropRandomEarly {
+		return defaultPolicy
+	}
+	return config.Params.DropPolicy
+}
+
+// earlyDrop returns true if random early detection is enabled and decides to drop an arriving message at the given
+// buffer load.
+func earlyDrop(bufferLoad int) bool {
+	if config.Params.DropPolicy != DropRandomEarly {
+		return false
+	}
+
+	threshold := config.Params.REDThreshold * float64(config.Params.MaxBuffer)
+	if float64(bufferLoad) <= threshold {
+		return false
+	}
+	probability := config.Params.REDMaxProbability * (float64(bufferLoad) - threshold) / (float64(config.Params.MaxBuffer) - threshold)
+
+	return rand.Float64() < probability
+}
+
+// selectDroppedMessage returns the index of the buffered message to drop according to the policy, ok is false if there
+// is no message to drop.
+func selectDroppedMessage(policy string, buffered []bufferedMessage) (dropped int, ok bool) {
+	maxLoad := 0.0
+	for _, candidate := range buffered {
+		if candidate.issuerLoad > maxLoad {
+			maxLoad = candidate.issuerLoad
+		}
+	}
+
+	dropped = -1
+	for i, candidate := range buffered {
+		if dropped == -1 {
+			if (policy != DropHeadFromLongest && policy != DropNewestFromLongest) || candidate.issuerLoad == maxLoad {
+				dropped = i
+			}
+			continue
+		}
+
+		current := buffered[dropped].message
+		switch policy {
+		case DropHead:
+			if candidate.message.IssuanceTime.Before(current.IssuanceTime) {
+				dropped = i
+			}
+		case DropTail:
+			if candidate.message.IssuanceTime.After(current.IssuanceTime) {
+				dropped = i
+			}
+		case DropHeadFromLongest:
+			if candidate.issuerLoad == maxLoad && candidate.message.IssuanceTime.Before(current.IssuanceTime) {
+				dropped = i
+			}
+		case DropNewestFromLongest:
+			if candidate.issuerLoad == maxLoad && candidate.message.IssuanceTime.After(current.IssuanceTime) {
+				dropped = i
+			}
+		case DropByBurn:
+			if candidate.message.ManaBurnValue < current.ManaBurnValue ||
+				(candidate.message.ManaBurnValue == current.ManaBurnValue && candidate.message.IssuanceTime.After(current.IssuanceTime)) {
+				dropped = i
+			}
+		default:
+			panic("invalid drop policy " + policy)
+		}
+	}
+
+	return dropped, dropped != -1
+}
+
+// issuerWork returns the total work of the messages of every issuer in the given messages.
+func issuerWork(messages []Message) (work map[network.PeerID]int) {
+	work = make(map[network.PeerID]int)
+	for _, message := range messages {
+		work[message.Issuer] += message.Work
+	}
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
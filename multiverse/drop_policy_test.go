@@ -0,0 +1,68 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+func TestSelectDroppedMessage(t *testing.T) {
+	now := time.Now()
+	buffered := []bufferedMessage{
+		{message: &Message{ID: 1, IssuanceTime: now.Add(2 * time.Second), ManaBurnValue: 3}, issuerLoad: 1},
+		{message: &Message{ID: 2, IssuanceTime: now, ManaBurnValue: 2}, issuerLoad: 1},
+		{message: &Message{ID: 3, IssuanceTime: now.Add(3 * time.Second), ManaBurnValue: 1}, issuerLoad: 2},
+		{message: &Message{ID: 4, IssuanceTime: now.Add(time.Second), ManaBurnValue: 1}, issuerLoad: 2},
+		{message: &Message{ID: 5, IssuanceTime: now.Add(4 * time.Second), ManaBurnValue: 5}, issuerLoad: 1},
+	}
+
+	for policy, expected := range map[string]MessageID{
+		DropHead:              2,
+		DropTail:              5,
+		DropHeadFromLongest:   4,
+		DropNewestFromLongest: 3,
+		DropByBurn:            3,
+	} {
+		dropped, ok := selectDroppedMessage(policy, buffered)
+		if !ok {
+			t.Errorf("%s: no message selected", policy)
+			continue
+		}
+		if buffered[dropped].message.ID != expected {
+			t.Errorf("%s: dropped message %d instead of %d", policy, buffered[dropped].message.ID, expected)
+		}
+	}
+}
+
+func TestSelectDroppedMessageEmptyBuffer(t *testing.T) {
+	for _, policy := range []string{DropHead, DropTail, DropHeadFromLongest, DropNewestFromLongest, DropByBurn} {
+		if _, ok := selectDroppedMessage(policy, nil); ok {
+			t.Errorf("%s: message selected from an empty buffer", policy)
+		}
+	}
+}
+
+func TestEarlyDrop(t *testing.T) {
+	defer func(policy string, maxBuffer int, threshold, probability float64) {
+		config.Params.DropPolicy, config.Params.MaxBuffer = policy, maxBuffer
+		config.Params.REDThreshold, config.Params.REDMaxProbability = threshold, probability
+	}(config.Params.DropPolicy, config.Params.MaxBuffer, config.Params.REDThreshold, config.Params.REDMaxProbability)
+	config.Params.MaxBuffer, config.Params.REDThreshold, config.Params.REDMaxProbability = 10, 0.5, 1
+
+	config.Params.DropPolicy = DropHead
+	if earlyDrop(config.Params.MaxBuffer) {
+		t.Error("early drop without random early detection")
+	}
+
+	config.Params.DropPolicy = DropRandomEarly
+	if earlyDrop(5) {
+		t.Error("early drop below the threshold")
+	}
+	if !earlyDrop(config.Params.MaxBuffer) {
+		t.Error("no early drop with a full buffer and a drop probability of 1")
+	}
+	if dropPolicy(DropByBurn) != DropByBurn {
+		t.Error("random early detection does not fall back to the default policy of the scheduler")
+	}
+}
@@ -0,0 +1,112 @@
+package multiverse
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region EquivocationDetector /////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// EquivocationNone only detects the equivocators.
+	EquivocationNone = "None"
+	// EquivocationIgnore ignores the messages of the detected equivocators in the approval weight and the opinions.
+	EquivocationIgnore = "Ignore"
+	// EquivocationSlash additionally removes the last vote of the detected equivocators from the opinions.
+	EquivocationSlash = "Slash"
+)
+
+// equivocationWindow is the number of sequence numbers that are remembered per issuer, an equivocation that arrives
+// later than that is not detected.
+const equivocationWindow = 1000
+
+// EquivocationDetector detects issuers that issued two different messages with the same sequence number.
+type EquivocationDetector struct {
+	Events *EquivocationDetectorEvents
+
+	tangle *Tangle
+	// sequenceNumbers holds, per issuer, the message seen for each of its recent sequence numbers.
+	sequenceNumbers    map[network.PeerID]map[uint64]MessageID
+	maxSequenceNumbers map[network.PeerID]uint64
+	equivocators       map[network.PeerID]bool
+
+	mutex sync.RWMutex
+}
+
+func NewEquivocationDetector(tangle *Tangle) *EquivocationDetector {
+	return &EquivocationDetector{
+		Events: &EquivocationDetectorEvents{
+			EquivocationDetected: events.NewEvent(equivocationDetectedEventCaller),
+		},
+		tangle:             tangle,
+		sequenceNumbers:    make(map[network.PeerID]map[uint64]MessageID),
+		maxSequenceNumbers: make(map[network.PeerID]uint64),
+		equivocators:       make(map[network.PeerID]bool),
+	}
+}
+
+// Track remembers the sequence number of the message and returns true if the issuer has already issued a different
+// message with the same sequence number. EquivocationDetected is triggered the first time an issuer is caught.
+func (e *EquivocationDetector) Track(message *Message) (equivocation bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	sequenceNumbers, exists := e.sequenceNumbers[message.Issuer]
+	if !exists {
+		sequenceNumbers = make(map[uint64]MessageID)
+		e.sequenceNumbers[message.Issuer] = sequenceNumbers
+	}
+	if messageID, seen := sequenceNumbers[message.SequenceNumber]; seen {
+		if messageID == message.ID {
+			return false
+		}
+		if !e.equivocators[message.Issuer] {
+			e.equivocators[message.Issuer] = true
+			e.Events.EquivocationDetected.Trigger(message.Issuer, message.SequenceNumber)
+		}
+		return true
+	}
+
+	sequenceNumbers[message.SequenceNumber] = message.ID
+	if message.SequenceNumber > e.maxSequenceNumbers[message.Issuer] {
+		e.maxSequenceNumbers[message.Issuer] = message.SequenceNumber
+	}
+	if len(sequenceNumbers) > 2*equivocationWindow {
+		for sequenceNumber := range sequenceNumbers {
+			if sequenceNumber+equivocationWindow < e.maxSequenceNumbers[message.Issuer] {
+				delete(sequenceNumbers, sequenceNumber)
+			}
+		}
+	}
+	return false
+}
+
+// IsEquivocator returns true if the issuer has been caught equivocating.
+func (e *EquivocationDetector) IsEquivocator(issuer network.PeerID) bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.equivocators[issuer]
+}
+
+// Ignored returns true if the messages of the issuer are ignored due to the EquivocationPolicy.
+func (e *EquivocationDetector) Ignored(issuer network.PeerID) bool {
+	return config.Params.EquivocationPolicy != EquivocationNone && e.IsEquivocator(issuer)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EquivocationDetectorEvents ///////////////////////////////////////////////////////////////////////////////////
+
+type EquivocationDetectorEvents struct {
+	// EquivocationDetected is triggered with the equivocator and the sequence number it used twice.
+	EquivocationDetected *events.Event
+}
+
+func equivocationDetectedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(network.PeerID, uint64))(params[0].(network.PeerID), params[1].(uint64))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
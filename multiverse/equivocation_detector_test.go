@@ -0,0 +1,79 @@
+package multiverse
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+func TestEquivocationDetector(t *testing.T) {
+	defer func(policy string) { config.Params.EquivocationPolicy = policy }(config.Params.EquivocationPolicy)
+	config.Params.EquivocationPolicy = EquivocationIgnore
+
+	tangle := newTestTangle(t, 3)
+	detector := NewEquivocationDetector(tangle)
+	detected := make([]network.PeerID, 0)
+	detector.Events.EquivocationDetected.Attach(events.NewClosure(func(issuer network.PeerID, sequenceNumber uint64) {
+		if sequenceNumber != 1 {
+			t.Errorf("equivocation detected at sequence number %d", sequenceNumber)
+		}
+		detected = append(detected, issuer)
+	}))
+
+	message := newTestMessage(1, true)
+	message.SequenceNumber = 1
+	if detector.Track(message) || detector.Track(message) {
+		t.Fatal("tracking the same message twice is an equivocation")
+	}
+	next := newTestMessage(1, true)
+	next.SequenceNumber = 2
+	other := newTestMessage(2, true)
+	other.SequenceNumber = 1
+	if detector.Track(next) || detector.Track(other) || detector.IsEquivocator(1) {
+		t.Fatal("equivocation detected for different sequence numbers or issuers")
+	}
+
+	equivocation := newTestMessage(1, true)
+	equivocation.SequenceNumber = 1
+	if !detector.Track(equivocation) || !detector.Track(equivocation) {
+		t.Fatal("equivocation not detected")
+	}
+	if !detector.IsEquivocator(1) || !detector.Ignored(1) || detector.IsEquivocator(2) {
+		t.Error("wrong equivocators")
+	}
+	if len(detected) != 1 || detected[0] != 1 {
+		t.Errorf("EquivocationDetected triggered for %v", detected)
+	}
+
+	config.Params.EquivocationPolicy = EquivocationNone
+	if detector.Ignored(1) {
+		t.Error("equivocator ignored without an EquivocationPolicy")
+	}
+}
+
+func TestEquivocationDetectorWindow(t *testing.T) {
+	detector := NewEquivocationDetector(newTestTangle(t, 2))
+
+	for sequenceNumber := uint64(1); sequenceNumber <= 2*equivocationWindow+1; sequenceNumber++ {
+		message := newTestMessage(1, true)
+		message.SequenceNumber = sequenceNumber
+		detector.Track(message)
+	}
+	if sequenceNumbers := len(detector.sequenceNumbers[1]); sequenceNumbers > equivocationWindow+1 {
+		t.Errorf("%d sequence numbers remembered", sequenceNumbers)
+	}
+
+	// equivocations older than the window are not detected anymore, recent ones are
+	old := newTestMessage(1, true)
+	old.SequenceNumber = 1
+	if detector.Track(old) {
+		t.Error("equivocation detected outside of the window")
+	}
+	recent := newTestMessage(1, true)
+	recent.SequenceNumber = 2 * equivocationWindow
+	if !detector.Track(recent) {
+		t.Error("equivocation not detected within the window")
+	}
+}
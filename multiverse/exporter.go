@@ -0,0 +1,367 @@
+package multiverse
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+// region ExportRequest ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExportRequest asks a node to export its tangle. It is sent through the socket of the node, so the tangle is exported
+// by the goroutine of the node itself and can be requested while the simulation is running.
+type ExportRequest struct {
+	// Formats contains the formats to write, any of 'dot', 'graphml' and 'json'.
+	Formats []string
+	// Directory is the directory the files are written to.
+	Directory string
+	// Name is the file name used for all formats, the format is appended as extension.
+	Name string
+	// StartSlot and EndSlot limit the export to the messages issued in the given slots, negative values are unbounded.
+	StartSlot SlotIndex
+	EndSlot   SlotIndex
+	// StartTime and EndTime limit the export to the messages issued in the given time since the genesis, zero values
+	// are unbounded.
+	StartTime time.Duration
+	EndTime   time.Duration
+	// Done receives the result of the export if set, it needs to be buffered as the node does not wait for the reader.
+	Done chan error
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TangleExporter ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// TangleExporter writes the tangle of a node to DOT, GraphML and JSON files for the visualization in Graphviz or Gephi.
+type TangleExporter struct {
+	tangle *Tangle
+}
+
+func NewTangleExporter(tangle *Tangle) *TangleExporter {
+	return &TangleExporter{
+		tangle: tangle,
+	}
+}
+
+// Export writes the messages of the requested slots in all requested formats. Only the references between exported
+// messages are written as edges.
+func (e *TangleExporter) Export(request *ExportRequest) error {
+	if err := os.MkdirAll(request.Directory, 0770); err != nil {
+		return err
+	}
+
+	exportedMessages := e.exportedMessages(request)
+	for _, format := range request.Formats {
+		var write func(io.Writer, []*exportedMessage) error
+		switch format {
+		case "dot":
+			write = e.writeDOT
+		case "graphml":
+			write = e.writeGraphML
+		case "json":
+			write = e.writeJSON
+		default:
+			return fmt.Errorf("unknown tangle export format %s", format)
+		}
+
+		if err := e.writeFile(path.Join(request.Directory, request.Name+"."+format), write, exportedMessages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *TangleExporter) writeFile(filePath string, write func(io.Writer, []*exportedMessage) error, exportedMessages []*exportedMessage) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return write(file, exportedMessages)
+}
+
+func (e *TangleExporter) exportedMessages(request *ExportRequest) (exportedMessages []*exportedMessage) {
+	messageIDs := NewMessageIDs()
+	for messageID := range e.tangle.Storage.MessagesInSlots(request.StartSlot, request.EndSlot) {
+		message := e.tangle.Storage.Message(messageID)
+		if message == nil || e.tangle.Storage.MessageMetadata(messageID) == nil {
+			continue
+		}
+
+		issuedAfter := message.IssuanceTime.Sub(e.tangle.Storage.genesisTime)
+		if (request.StartTime > 0 && issuedAfter < request.StartTime) || (request.EndTime > 0 && issuedAfter > request.EndTime) {
+			continue
+		}
+		messageIDs.Add(messageID)
+	}
+
+	for messageID := range messageIDs {
+		message := e.tangle.Storage.Message(messageID)
+		messageMetadata := e.tangle.Storage.MessageMetadata(messageID)
+
+		exportedMessages = append(exportedMessages, &exportedMessage{
+			ID:               message.ID,
+			Issuer:           int64(message.Issuer),
+			Validation:       message.Validation,
+			Payload:          message.Payload.String(),
+			InheritedColor:   messageMetadata.InheritedColor().String(),
+			Slot:             e.tangle.Storage.SlotIndex(message.IssuanceTime),
+			IssuanceTime:     e.sinceGenesis(message.IssuanceTime),
+			ArrivalTime:      e.sinceGenesis(messageMetadata.ArrivalTime()),
+			ScheduleTime:     e.sinceGenesis(messageMetadata.ScheduleTime()),
+			ConfirmationTime: e.sinceGenesis(messageMetadata.ConfirmationTime()),
+			OrphanTime:       e.sinceGenesis(messageMetadata.OrphanTime()),
+			Weight:           messageMetadata.Weight(),
+			StrongParents:    exportedParents(message.StrongParents, messageIDs),
+			WeakParents:      exportedParents(message.WeakParents, messageIDs),
+		})
+	}
+	sort.Slice(exportedMessages, func(i, j int) bool {
+		return exportedMessages[i].ID < exportedMessages[j].ID
+	})
+
+	return
+}
+
+// sinceGenesis returns the time since the genesis in nanoseconds, or -1 if the time is not set.
+func (e *TangleExporter) sinceGenesis(t time.Time) int64 {
+	if t.IsZero() {
+		return -1
+	}
+	return t.Sub(e.tangle.Storage.genesisTime).Nanoseconds()
+}
+
+func (e *TangleExporter) writeDOT(w io.Writer, exportedMessages []*exportedMessage) (err error) {
+	if _, err = fmt.Fprintf(w, "digraph \"node %d\" {\n\trankdir=RL;\n", e.tangle.Peer.ID); err != nil {
+		return
+	}
+	for _, message := range exportedMessages {
+		shape := "ellipse"
+		if message.Validation {
+			shape = "box"
+		}
+		if _, err = fmt.Fprintf(w, "\t%d [label=\"%d\", shape=%s, color=%q, style=%q, status=%q, issuer=%d, validation=%t, payload=%q, "+
+			"inheritedColor=%q, slot=%d, issuanceTime=%d, arrivalTime=%d, scheduleTime=%d, confirmationTime=%d, orphanTime=%d, weight=%d];\n",
+			message.ID, message.ID, shape, message.dotColor(), message.dotStyle(), message.status(), message.Issuer, message.Validation, message.Payload, message.InheritedColor, message.Slot,
+			message.IssuanceTime, message.ArrivalTime, message.ScheduleTime, message.ConfirmationTime, message.OrphanTime, message.Weight); err != nil {
+			return
+		}
+	}
+	for _, message := range exportedMessages {
+		for _, parentID := range message.StrongParents {
+			if _, err = fmt.Fprintf(w, "\t%d -> %d;\n", message.ID, parentID); err != nil {
+				return
+			}
+		}
+		for _, parentID := range message.WeakParents {
+			if _, err = fmt.Fprintf(w, "\t%d -> %d [style=dashed, type=\"weak\"];\n", message.ID, parentID); err != nil {
+				return
+			}
+		}
+	}
+	_, err = fmt.Fprintln(w, "}")
+
+	return
+}
+
+func (e *TangleExporter) writeGraphML(w io.Writer, exportedMessages []*exportedMessage) error {
+	graphML := &graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "issuer", For: "node", Name: "issuer", Type: "long"},
+			{ID: "validation", For: "node", Name: "validation", Type: "boolean"},
+			{ID: "payload", For: "node", Name: "payload", Type: "string"},
+			{ID: "inheritedColor", For: "node", Name: "inheritedColor", Type: "string"},
+			{ID: "slot", For: "node", Name: "slot", Type: "long"},
+			{ID: "issuanceTime", For: "node", Name: "issuanceTime", Type: "long"},
+			{ID: "arrivalTime", For: "node", Name: "arrivalTime", Type: "long"},
+			{ID: "scheduleTime", For: "node", Name: "scheduleTime", Type: "long"},
+			{ID: "confirmationTime", For: "node", Name: "confirmationTime", Type: "long"},
+			{ID: "orphanTime", For: "node", Name: "orphanTime", Type: "long"},
+			{ID: "weight", For: "node", Name: "weight", Type: "long"},
+			{ID: "type", For: "edge", Name: "type", Type: "string"},
+		},
+		Graph: graphMLGraph{
+			ID:          fmt.Sprintf("node %d", e.tangle.Peer.ID),
+			EdgeDefault: "directed",
+		},
+	}
+
+	for _, message := range exportedMessages {
+		graphML.Graph.Nodes = append(graphML.Graph.Nodes, graphMLNode{
+			ID: fmt.Sprint(message.ID),
+			Data: []graphMLData{
+				{Key: "issuer", Value: fmt.Sprint(message.Issuer)},
+				{Key: "validation", Value: fmt.Sprint(message.Validation)},
+				{Key: "payload", Value: message.Payload},
+				{Key: "inheritedColor", Value: message.InheritedColor},
+				{Key: "slot", Value: fmt.Sprint(message.Slot)},
+				{Key: "issuanceTime", Value: fmt.Sprint(message.IssuanceTime)},
+				{Key: "arrivalTime", Value: fmt.Sprint(message.ArrivalTime)},
+				{Key: "scheduleTime", Value: fmt.Sprint(message.ScheduleTime)},
+				{Key: "confirmationTime", Value: fmt.Sprint(message.ConfirmationTime)},
+				{Key: "orphanTime", Value: fmt.Sprint(message.OrphanTime)},
+				{Key: "weight", Value: fmt.Sprint(message.Weight)},
+			},
+		})
+		for _, parentID := range message.StrongParents {
+			graphML.Graph.Edges = append(graphML.Graph.Edges, graphMLEdge{
+				Source: fmt.Sprint(message.ID),
+				Target: fmt.Sprint(parentID),
+				Data:   []graphMLData{{Key: "type", Value: "strong"}},
+			})
+		}
+		for _, parentID := range message.WeakParents {
+			graphML.Graph.Edges = append(graphML.Graph.Edges, graphMLEdge{
+				Source: fmt.Sprint(message.ID),
+				Target: fmt.Sprint(parentID),
+				Data:   []graphMLData{{Key: "type", Value: "weak"}},
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", " ")
+	return encoder.Encode(graphML)
+}
+
+func (e *TangleExporter) writeJSON(w io.Writer, exportedMessages []*exportedMessage) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(struct {
+		Node     int64              `json:"node"`
+		Messages []*exportedMessage `json:"messages"`
+	}{
+		Node:     int64(e.tangle.Peer.ID),
+		Messages: exportedMessages,
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region exportedMessage //////////////////////////////////////////////////////////////////////////////////////////////
+
+// exportedMessage holds the attributes of a message written by the TangleExporter, times are in nanoseconds since the
+// genesis and -1 if not set.
+type exportedMessage struct {
+	ID               MessageID   `json:"id"`
+	Issuer           int64       `json:"issuer"`
+	Validation       bool        `json:"validation"`
+	Payload          string      `json:"payload"`
+	InheritedColor   string      `json:"inheritedColor"`
+	Slot             SlotIndex   `json:"slot"`
+	IssuanceTime     int64       `json:"issuanceTime"`
+	ArrivalTime      int64       `json:"arrivalTime"`
+	ScheduleTime     int64       `json:"scheduleTime"`
+	ConfirmationTime int64       `json:"confirmationTime"`
+	OrphanTime       int64       `json:"orphanTime"`
+	Weight           uint64      `json:"weight"`
+	StrongParents    []MessageID `json:"strongParents"`
+	WeakParents      []MessageID `json:"weakParents"`
+}
+
+// dotColor returns the DOT color of the color the message inherited, messages without a color are black.
+func (m *exportedMessage) dotColor() string {
+	switch m.InheritedColor {
+	case Blue.String():
+		return "blue"
+	case Red.String():
+		return "red"
+	case Green.String():
+		return "green"
+	default:
+		return "black"
+	}
+}
+
+// dotStyle draws confirmed messages bold, orphaned messages dashed and undecided messages dotted.
+func (m *exportedMessage) dotStyle() string {
+	switch m.status() {
+	case "confirmed":
+		return "bold"
+	case "orphaned":
+		return "dashed"
+	default:
+		return "dotted"
+	}
+}
+
+// status returns whether the message is confirmed, orphaned or still pending.
+func (m *exportedMessage) status() string {
+	switch {
+	case m.ConfirmationTime >= 0:
+		return "confirmed"
+	case m.OrphanTime >= 0:
+		return "orphaned"
+	default:
+		return "pending"
+	}
+}
+
+// exportedParents returns the sorted parents that are exported as well.
+func exportedParents(parents MessageIDs, exportedMessageIDs MessageIDs) (exported []MessageID) {
+	exported = make([]MessageID, 0, len(parents))
+	for parentID := range parents {
+		if _, exists := exportedMessageIDs[parentID]; exists {
+			exported = append(exported, parentID)
+		}
+	}
+	sort.Slice(exported, func(i, j int) bool {
+		return exported[i] < exported[j]
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region graphML //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
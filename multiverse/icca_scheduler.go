@@ -35,28 +35,51 @@ type ICCAScheduler struct {
 	// nonReadyMapMutex sync.RWMutex
 	nonReadyMap map[MessageID]*Message
 
-	accessMana   map[network.PeerID]float64
 	deficits     map[network.PeerID]float64
 	quanta       map[network.PeerID]float64
 	issuerQueues map[network.PeerID]*IssuerQueue
 	roundRobin   *ring.Ring
 	readyLen     int
+	// readyWork and issuerWork hold the work of all ready messages and of the messages in each issuer queue
+	readyWork  int
+	issuerWork map[network.PeerID]int
+	// busyTicks is the number of scheduler ticks the last scheduled message still occupies
+	busyTicks int
+	// validationLane holds the ready validation blocks if ValidationScheduling is 'Lane', it is rate limited by a
+	// token bucket of ValidationLaneBurst tokens
+	validationLane []*Message
+	laneTokens     float64
+	laneRefillTime time.Time
 
 	mutex sync.Mutex
 
 	events *SchedulerEvents
 }
 
+func NewICCAScheduler(tangle *Tangle) Scheduler {
+	return &ICCAScheduler{
+		tangle:       tangle,
+		nonReadyMap:  make(map[MessageID]*Message),
+		deficits:     make(map[network.PeerID]float64, config.Params.NodesCount),
+		quanta:       make(map[network.PeerID]float64, config.Params.NodesCount),
+		issuerQueues: make(map[network.PeerID]*IssuerQueue, config.Params.NodesCount),
+		issuerWork:   make(map[network.PeerID]int, config.Params.NodesCount),
+		roundRobin:   ring.New(config.Params.NodesCount),
+		events:       newSchedulerEvents(),
+	}
+}
+
 func (s *ICCAScheduler) Setup() {
-	// setup the initial AccessMana, deficits and quanta when the peer ID is created
+	// setup the initial deficits and quanta when the peer ID is created
 	for id := 0; id < config.Params.NodesCount; id++ {
-		s.accessMana[network.PeerID(id)] = 0.0
 		s.deficits[network.PeerID(id)] = 0.0
 		idBandwidth := s.tangle.BandwidthDistribution.Bandwidth(network.PeerID(id))
 		s.quanta[network.PeerID(id)] = float64(idBandwidth) / float64(config.Params.SchedulingRate)
 	}
 	// initialise the issuer queues
 	s.initQueues()
+	s.laneTokens = config.Params.ValidationLaneBurst
+	s.laneRefillTime = time.Now()
 	s.events.MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
 		s.tangle.Peer.GossipNetworkMessage(s.tangle.Storage.Message(messageID))
 		s.updateChildrenReady(messageID)
@@ -64,7 +87,14 @@ func (s *ICCAScheduler) Setup() {
 		//	s.tangle.Peer.ID, messageID)
 	}))
 	s.events.MessageDropped.Attach(events.NewClosure(func(messageID MessageID) {
-		s.tangle.Storage.MessageMetadata(messageID).SetDropTime(time.Now())
+		if messageMetadata := s.tangle.Storage.MessageMetadata(messageID); messageMetadata != nil {
+			messageMetadata.SetDropTime(time.Now())
+		}
+	}))
+	s.tangle.Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs MessageIDs) {
+		for messageID := range messageIDs {
+			delete(s.nonReadyMap, messageID)
+		}
 	}))
 	s.tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *Message, messageMetadata *MessageMetadata, weight uint64, messageIDCounter int64) {
 		if config.Params.ConfEligible {
@@ -95,54 +125,40 @@ func (s *ICCAScheduler) setReady(messageID MessageID) {
 	// defer s.nonReadyMapMutex.Unlock()
 	if m, exists := s.nonReadyMap[messageID]; exists {
 		delete(s.nonReadyMap, messageID)
-		s.push(m)
-	}
-}
-
-func (s *ICCAScheduler) IncrementAccessMana(schedulingRate float64) {
-	bandwidth := s.tangle.BandwidthDistribution.Bandwidths()
-	totalBandwidth := config.Params.SchedulingRate
-	// every time something is scheduled, we add this much mana in total\
-	mana := float64(10)
-	for id := range s.accessMana {
-		s.accessMana[id] += mana * float64(bandwidth[id]) / float64(totalBandwidth)
+		s.tangle.Storage.MessageMetadata(messageID).SetReadyTime(time.Now())
+		if m.Validation {
+			s.pushValidation(m)
+		} else {
+			s.push(m)
+		}
 	}
 }
 
-func (s *ICCAScheduler) DecreaseNodeAccessMana(nodeID network.PeerID, manaIncrement float64) (newAccessMana float64) {
-	s.accessMana[nodeID] -= manaIncrement
-	newAccessMana = s.accessMana[nodeID]
-	return newAccessMana
-}
-
-func (s *ICCAScheduler) BurnValue(issuanceTime time.Time) (float64, bool) {
-	slotIndex := s.tangle.Storage.SlotIndex(issuanceTime)
-	RMC := s.tangle.Storage.RMC(slotIndex)
-	return RMC, s.GetNodeAccessMana(s.tangle.Peer.ID) >= RMC
-}
-
 func (s *ICCAScheduler) EnqueueMessage(messageID MessageID) {
-	s.tangle.Storage.MessageMetadata(messageID).SetEnqueueTime(time.Now())
+	enqueueTime := time.Now()
+	s.tangle.Storage.MessageMetadata(messageID).SetEnqueueTime(enqueueTime)
 	m := s.tangle.Storage.Message(messageID)
 
-	// if this message is a validation block, skip the scheduler.
-	if m.Validation {
-		s.tangle.Storage.MessageMetadata(m.ID).SetScheduleTime(time.Now())
-		s.updateChildrenReady(m.ID)
-		s.events.MessageScheduled.Trigger(m.ID)
-	}
-
-	// if this node is a spammer, skip the scheduler.
-	if m.Issuer == s.tangle.Peer.ID && config.Params.BurnPolicies[m.Issuer] == 0 {
-		s.tangle.Storage.MessageMetadata(m.ID).SetScheduleTime(time.Now())
-		s.updateChildrenReady(m.ID)
-		s.events.MessageScheduled.Trigger(m.ID)
+	// validation blocks skip the scheduler unless they go through the validation lane, and so do the own messages of a
+	// spammer.
+	if (m.Validation && config.Params.ValidationScheduling != ValidationLane) ||
+		(m.Issuer == s.tangle.Peer.ID && config.Params.BurnPolicies[m.Issuer] == 0) {
+		s.tangle.Storage.MessageMetadata(m.ID).SetReadyTime(enqueueTime)
+		s.schedule(m)
+		return
 	}
 	// Check if the message is ready to decide which queue to append to
 	if s.tangle.Storage.isReady(messageID) {
 		//log.Debugf("Ready Message Enqueued")
 		s.tangle.Storage.MessageMetadata(messageID).SetReady()
-		s.push(m)
+		s.tangle.Storage.MessageMetadata(messageID).SetReadyTime(enqueueTime)
+		if m.Validation {
+			s.pushValidation(m)
+		} else if earlyDrop(s.ReadyWork()) {
+			s.events.MessageDropped.Trigger(messageID)
+		} else {
+			s.push(m)
+		}
 	} else {
 		//log.Debug("Not Ready Message Enqueued")
 		s.tangle.Storage.MessageMetadata(messageID).SetReady()
@@ -154,22 +170,59 @@ func (s *ICCAScheduler) EnqueueMessage(messageID MessageID) {
 	s.BufferManagement()
 }
 
+// BufferManagement drops messages according to the DropPolicy until the work of the ready messages fits into
+// MaxBuffer. By default the head of the issuer queue with the most work relative to its quantum is dropped.
 func (s *ICCAScheduler) BufferManagement() {
-	for s.ReadyLen() > config.Params.MaxBuffer {
-		issuerID := 0
-		maxScaledLen := 0.0
-		for id := 0; id < config.Params.NodesCount; id++ {
-			scaledLen := float64(s.IssuerQueueLen(network.PeerID(id))) / s.quanta[network.PeerID(id)]
-			if scaledLen >= maxScaledLen {
-				maxScaledLen = scaledLen
-				issuerID = id
-			}
+	for s.ReadyWork() > config.Params.MaxBuffer {
+		issuerID, index, ok := s.droppedMessage()
+		if !ok {
+			return
+		}
+		m := s.remove(issuerID, index)
+		s.events.MessageDropped.Trigger(m.ID)
+	}
+}
+
+// droppedMessage returns the issuer queue and the index in it of the message selected by the DropPolicy, ok is false if
+// the issuer queues are empty.
+func (s *ICCAScheduler) droppedMessage() (issuerID network.PeerID, index int, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	type location struct {
+		issuerID network.PeerID
+		index    int
+	}
+	buffered := make([]bufferedMessage, 0, s.readyLen)
+	locations := make([]location, 0, s.readyLen)
+	for id := 0; id < config.Params.NodesCount; id++ {
+		issuerQueue := *s.issuerQueues[network.PeerID(id)]
+		issuerLoad := float64(s.issuerWork[network.PeerID(id)]) / s.quanta[network.PeerID(id)]
+		for i := range issuerQueue {
+			buffered = append(buffered, bufferedMessage{message: &issuerQueue[i], issuerLoad: issuerLoad})
+			locations = append(locations, location{issuerID: network.PeerID(id), index: i})
 		}
-		s.pop(network.PeerID(issuerID)) // drop head
 	}
+	droppedIndex, ok := selectDroppedMessage(dropPolicy(DropHeadFromLongest), buffered)
+	if !ok {
+		return 0, 0, false
+	}
+	return locations[droppedIndex].issuerID, locations[droppedIndex].index, true
 }
 
 func (s *ICCAScheduler) ScheduleMessage() {
+	// every call is a tick worth one work unit, so a message with more work keeps the scheduler busy for several ticks
+	if s.busyTicks > 0 {
+		s.busyTicks--
+		return
+	}
+	// the validation lane has priority over the data messages as long as it has tokens
+	if m := s.popValidation(); m != nil {
+		s.busyTicks = m.ScheduledWork() - 1
+		if !s.tangle.Storage.IsPruned(m.ID) {
+			s.schedule(m)
+		}
+		return
+	}
 	rounds, selectedIssuerID := s.selectIssuer()
 	if selectedIssuerID == network.PeerID(-1) {
 		return
@@ -186,9 +239,18 @@ func (s *ICCAScheduler) ScheduleMessage() {
 	// now the ring is pointing to the selected issuer and deficits are updated.
 	// pop the message from the chosen issuer's queue
 	m := s.pop(s.roundRobin.Value.(network.PeerID))
-	// decrement its deficit
-	s.incrementDeficit(s.roundRobin.Value.(network.PeerID), -1) // assumes work==1
-	// schedule the message
+	// decrement its deficit by the work of the message
+	s.incrementDeficit(s.roundRobin.Value.(network.PeerID), -float64(m.ScheduledWork()))
+	s.busyTicks = m.ScheduledWork() - 1
+	// the message was pruned while it was waiting in the queue
+	if s.tangle.Storage.IsPruned(m.ID) {
+		return
+	}
+	s.schedule(&m)
+}
+
+// schedule marks the message as scheduled and triggers the MessageScheduled event.
+func (s *ICCAScheduler) schedule(m *Message) {
 	s.tangle.Storage.MessageMetadata(m.ID).SetScheduleTime(time.Now())
 	s.updateChildrenReady(m.ID)
 	s.events.MessageScheduled.Trigger(m.ID)
@@ -204,7 +266,7 @@ func (s *ICCAScheduler) selectIssuer() (rounds float64, issuerID network.PeerID)
 			continue
 		}
 		id := s.roundRobin.Value.(network.PeerID)
-		r := (math.Max(1-s.Deficit(network.PeerID(id)), 0) / s.quanta[network.PeerID(id)])
+		r := (math.Max(float64(s.headWork(id))-s.Deficit(network.PeerID(id)), 0) / s.quanta[network.PeerID(id)])
 		if r < rounds {
 			rounds = r
 			issuerID = id
@@ -221,7 +283,7 @@ func (s *ICCAScheduler) Events() *SchedulerEvents {
 func (s *ICCAScheduler) ReadyLen() int {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.readyLen
+	return s.readyLen + len(s.validationLane)
 }
 
 func (s *ICCAScheduler) NonReadyLen() int {
@@ -230,11 +292,6 @@ func (s *ICCAScheduler) NonReadyLen() int {
 	return len(s.nonReadyMap)
 }
 
-func (s *ICCAScheduler) GetNodeAccessMana(nodeID network.PeerID) (mana float64) {
-	mana = s.accessMana[nodeID]
-	return mana
-}
-
 func (s *ICCAScheduler) GetMaxManaBurn() (maxManaBurn float64) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -253,18 +310,49 @@ func (s *ICCAScheduler) IssuerQueueLen(issuer network.PeerID) int {
 	return s.issuerQueues[issuer].Len()
 }
 
+// IssuerQueueWork returns the total work of the messages in the queue of the issuer.
+func (s *ICCAScheduler) IssuerQueueWork(issuer network.PeerID) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.issuerWork[issuer]
+}
+
+// ReadyWork returns the total work of all ready messages.
+func (s *ICCAScheduler) ReadyWork() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.readyWork
+}
+
+// headWork returns the work of the next message in the queue of the issuer.
+func (s *ICCAScheduler) headWork(issuer network.PeerID) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return (*s.issuerQueues[issuer])[0].ScheduledWork()
+}
+
 func (s *ICCAScheduler) push(m *Message) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	heap.Push(s.issuerQueues[m.Issuer], *m)
 	s.readyLen += 1
+	s.readyWork += m.ScheduledWork()
+	s.issuerWork[m.Issuer] += m.ScheduledWork()
 }
 
 func (s *ICCAScheduler) pop(issuer network.PeerID) Message {
+	return s.remove(issuer, 0)
+}
+
+// remove removes the message at the given index of the issuer queue.
+func (s *ICCAScheduler) remove(issuer network.PeerID, index int) Message {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	m := heap.Remove(s.issuerQueues[issuer], index).(Message)
 	s.readyLen -= 1
-	return heap.Pop(s.issuerQueues[issuer]).(Message)
+	s.readyWork -= m.ScheduledWork()
+	s.issuerWork[issuer] -= m.ScheduledWork()
+	return m
 }
 
 func (s *ICCAScheduler) Deficit(issuer network.PeerID) float64 {
@@ -278,14 +366,54 @@ func (s *ICCAScheduler) incrementDeficit(issuer network.PeerID, delta float64) {
 	defer s.mutex.Unlock()
 	s.deficits[issuer] = math.Min(
 		s.deficits[issuer]+delta,
-		config.Params.MaxDeficit,
+		// the deficit has to be able to cover the largest message, otherwise it could never be scheduled
+		math.Max(config.Params.MaxDeficit, float64(MaxMessageWork())),
 	)
 }
 
-func (s *ICCAScheduler) RateSetter() bool {
-	if s.ReadyLen() == 0 || config.Params.BurnPolicies[s.tangle.Peer.ID] == 0 {
-		return true
+// region Validation Lane //////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// ValidationBypass schedules validation blocks right away.
+	ValidationBypass = "Bypass"
+	// ValidationLane schedules validation blocks from a rate limited lane with priority over the data messages.
+	ValidationLane = "Lane"
+)
+
+// pushValidation appends a ready validation block to the validation lane and drops the oldest block of the lane if it
+// holds more than MaxBuffer blocks.
+func (s *ICCAScheduler) pushValidation(m *Message) {
+	s.mutex.Lock()
+	s.validationLane = append(s.validationLane, m)
+	var dropped *Message
+	if len(s.validationLane) > config.Params.MaxBuffer {
+		dropped = s.validationLane[0]
+		s.validationLane = s.validationLane[1:]
+	}
+	s.mutex.Unlock()
+
+	if dropped != nil {
+		s.events.MessageDropped.Trigger(dropped.ID)
+	}
+}
+
+// popValidation returns the oldest block of the validation lane if the lane has a token left, nil otherwise.
+func (s *ICCAScheduler) popValidation() (m *Message) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	refillRate := config.Params.ValidationLaneRate / float64(config.Params.SlowdownFactor)
+	s.laneTokens = math.Min(s.laneTokens+now.Sub(s.laneRefillTime).Seconds()*refillRate, config.Params.ValidationLaneBurst)
+	s.laneRefillTime = now
+
+	if len(s.validationLane) == 0 || s.laneTokens < 1 {
+		return nil
 	}
-	qlen := s.IssuerQueueLen(s.tangle.Peer.ID)
-	return int(s.Deficit(s.tangle.Peer.ID)) >= qlen+1
+	s.laneTokens--
+	m = s.validationLane[0]
+	s.validationLane = s.validationLane[1:]
+	return m
 }
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
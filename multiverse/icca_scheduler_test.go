@@ -0,0 +1,74 @@
+package multiverse
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// newTestICCAScheduler returns the ICCA scheduler of a test tangle with a buffer of the given size.
+func newTestICCAScheduler(t *testing.T, validatorCount, maxBuffer int) (*Tangle, *ICCAScheduler) {
+	schedulerType, bufferSize := config.Params.SchedulerType, config.Params.MaxBuffer
+	t.Cleanup(func() { config.Params.SchedulerType, config.Params.MaxBuffer = schedulerType, bufferSize })
+	config.Params.SchedulerType, config.Params.MaxBuffer = "ICCA+", maxBuffer
+
+	tangle := newTestTangle(t, validatorCount)
+	return tangle, tangle.Scheduler.(*ICCAScheduler)
+}
+
+func TestICCASchedulerVariableWork(t *testing.T) {
+	tangle, scheduler := newTestICCAScheduler(t, 3, 1000)
+	defer func(distribution string, maxWork int) {
+		config.Params.WorkDistribution, config.Params.MaxWork = distribution, maxWork
+	}(config.Params.WorkDistribution, config.Params.MaxWork)
+	config.Params.WorkDistribution, config.Params.MaxWork = "Uniform", 3
+
+	scheduledWork := make(map[network.PeerID]int)
+	scheduler.Events().MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		message := tangle.Storage.Message(messageID)
+		scheduledWork[message.Issuer] += message.Work
+	}))
+
+	// both issuers have the same quantum, so they get the same share of the work units, not of the messages
+	for i := 0; i < 30; i++ {
+		heavy := newTestMessage(1, false)
+		heavy.Work = 3
+		tangle.ProcessMessage(heavy)
+		tangle.ProcessMessage(newTestMessage(2, false))
+	}
+	for tick := 0; tick < 40; tick++ {
+		scheduler.ScheduleMessage()
+	}
+
+	if difference := scheduledWork[1] - scheduledWork[2]; difference < -3 || difference > 3 {
+		t.Fatalf("unfair share of the scheduled work: %v", scheduledWork)
+	}
+	// the last scheduled message might still keep the scheduler busy after the last tick
+	if scheduledWork[1]+scheduledWork[2] > 40+2 {
+		t.Fatalf("scheduled more work than ticks: %v", scheduledWork)
+	}
+}
+
+func TestICCASchedulerZeroWork(t *testing.T) {
+	tangle, scheduler := newTestICCAScheduler(t, 3, 2)
+
+	dropped := 0
+	scheduler.Events().MessageDropped.Attach(events.NewClosure(func(MessageID) { dropped++ }))
+
+	// messages without work are charged one work unit, so they count towards the buffer and can be dropped
+	for i := 0; i < 3; i++ {
+		message := newTestMessage(1, false)
+		message.Work = 0
+		tangle.ProcessMessage(message)
+	}
+	if dropped != 1 || scheduler.ReadyWork() != 2 || scheduler.IssuerQueueWork(1) != 2 {
+		t.Fatalf("dropped %d messages, ready work %d, issuer work %d", dropped, scheduler.ReadyWork(), scheduler.IssuerQueueWork(1))
+	}
+
+	scheduler.ScheduleMessage()
+	if scheduler.ReadyWork() != 1 || scheduler.IssuerQueueWork(1) != 1 {
+		t.Fatalf("message not scheduled, ready work %d", scheduler.ReadyWork())
+	}
+}
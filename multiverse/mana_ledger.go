@@ -0,0 +1,191 @@
+package multiverse
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region ManaLedgerMode ///////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// LocalManaLedger updates the balances as the node sees the messages, so the views of the nodes diverge.
+	LocalManaLedger = "Local"
+	// CommittedManaLedger derives the balances from the accepted messages of the committed slots only, so all nodes
+	// that accepted the same messages agree on the balances.
+	CommittedManaLedger = "Committed"
+)
+
+func committedManaLedger() bool {
+	return config.Params.ManaLedgerMode == CommittedManaLedger
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region manaAccounts /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// manaAccounts holds the mana balances of all nodes as seen by the local node according to the ManaLedgerMode. The
+// mana managers embed it and only define the initial mana and the generation of mana.
+type manaAccounts struct {
+	tangle     *Tangle
+	accessMana map[network.PeerID]float64
+	// lastSlot is the first slot whose generation was not applied yet
+	lastSlot SlotIndex
+
+	// pendingBurns are the burns of the own messages by the slot of the message, for the slots not committed yet
+	pendingBurns map[SlotIndex]float64
+	// acceptedMessages are the accepted messages per slot that is not committed yet
+	acceptedMessages map[SlotIndex][]*Message
+
+	mutex sync.RWMutex
+}
+
+func newManaAccounts(tangle *Tangle) *manaAccounts {
+	return &manaAccounts{
+		tangle:           tangle,
+		accessMana:       make(map[network.PeerID]float64, config.Params.NodesCount),
+		pendingBurns:     make(map[SlotIndex]float64),
+		acceptedMessages: make(map[SlotIndex][]*Message),
+	}
+}
+
+// setup sets the initial mana of all nodes and applies the mana transfers, in the Committed mode once the slot of the
+// transferring message is committed.
+func (a *manaAccounts) setup(initialMana func(network.PeerID) float64) {
+	for id := 0; id < config.Params.NodesCount; id++ {
+		a.accessMana[network.PeerID(id)] = initialMana(network.PeerID(id))
+	}
+
+	if !committedManaLedger() {
+		a.tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(_ MessageID, message *Message, _ *MessageMetadata) {
+			if message.ManaTransfer != nil {
+				a.mutex.Lock()
+				a.transfer(message.Issuer, message.ManaTransfer)
+				a.mutex.Unlock()
+			}
+		}))
+		return
+	}
+	a.tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(_ MessageID, message *Message, _ *MessageMetadata) {
+		a.bookPendingBurn(message)
+	}))
+	a.tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *Message, messageMetadata *MessageMetadata, weight uint64, messageIDCounter int64) {
+		a.bookAcceptedMessage(message)
+	}))
+}
+
+// bookPendingBurn remembers the burn of an own message until the slot of the message is committed.
+func (a *manaAccounts) bookPendingBurn(message *Message) {
+	if message.Issuer != a.tangle.Peer.ID {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if slotIndex := a.tangle.Storage.SlotIndex(message.IssuanceTime); slotIndex >= a.lastSlot {
+		a.pendingBurns[slotIndex] += message.ManaBurnValue
+	}
+}
+
+// bookAcceptedMessage accounts the message in its slot. Messages accepted after their slot was committed are not part
+// of the commitment and are not accounted, so the balances do not depend on when the node accepted them.
+func (a *manaAccounts) bookAcceptedMessage(message *Message) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if slotIndex := a.tangle.Storage.SlotIndex(message.IssuanceTime); slotIndex >= a.lastSlot {
+		a.acceptedMessages[slotIndex] = append(a.acceptedMessages[slotIndex], message)
+	}
+}
+
+// increment applies the generation of mana. In the Local mode tickGeneration is applied on every scheduler tick, or
+// slotGeneration once per slot if there is no tickGeneration. In the Committed mode slotGeneration and the burns of
+// the accepted messages are applied for every slot that got committed since the last call.
+func (a *manaAccounts) increment(tickGeneration, slotGeneration func(network.PeerID, float64) float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	currentSlot := a.tangle.Storage.SlotIndex(time.Now())
+	if !committedManaLedger() {
+		if tickGeneration != nil {
+			a.generate(tickGeneration)
+			return
+		}
+		for ; a.lastSlot < currentSlot; a.lastSlot++ {
+			a.generate(slotGeneration)
+		}
+		return
+	}
+
+	committedSlot := currentSlot - SlotIndex(config.Params.MinCommittableAge/config.Params.SlotTime)
+	for ; a.lastSlot < committedSlot; a.lastSlot++ {
+		a.generate(slotGeneration)
+		for _, message := range a.acceptedMessages[a.lastSlot] {
+			a.accessMana[message.Issuer] -= message.ManaBurnValue
+			if message.ManaTransfer != nil {
+				a.transfer(message.Issuer, message.ManaTransfer)
+			}
+		}
+		delete(a.acceptedMessages, a.lastSlot)
+		delete(a.pendingBurns, a.lastSlot)
+	}
+}
+
+// DecreaseNodeAccessMana burns mana of the node. In the Committed mode the burns are taken from the own messages as they
+// are stored and from the committed slots, so it only returns the balance.
+func (a *manaAccounts) DecreaseNodeAccessMana(nodeID network.PeerID, manaIncrement float64) (newAccessMana float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if !committedManaLedger() {
+		a.accessMana[nodeID] -= manaIncrement
+		return a.accessMana[nodeID]
+	}
+	return a.balance(nodeID)
+}
+
+func (a *manaAccounts) GetNodeAccessMana(nodeID network.PeerID) (mana float64) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.balance(nodeID)
+}
+
+// balance returns the mana of the node, the own mana is reduced by the burns that are not committed yet.
+func (a *manaAccounts) balance(nodeID network.PeerID) (mana float64) {
+	mana = a.accessMana[nodeID]
+	if nodeID == a.tangle.Peer.ID {
+		for _, burn := range a.pendingBurns {
+			mana -= burn
+		}
+	}
+	return
+}
+
+func (a *manaAccounts) generate(generation func(network.PeerID, float64) float64) {
+	for id, mana := range a.accessMana {
+		a.accessMana[id] = generation(id, mana)
+	}
+}
+
+// transfer moves the mana of the transfer from the issuer to the receiver, limited to the mana left to the issuer.
+func (a *manaAccounts) transfer(issuer network.PeerID, transfer *ManaTransfer) {
+	amount := math.Min(transfer.Amount, math.Max(a.accessMana[issuer], 0))
+	a.accessMana[issuer] -= amount
+	a.accessMana[transfer.Receiver] = capMana(a.accessMana[transfer.Receiver] + amount)
+}
+
+// ticksPerSlot returns the number of scheduler ticks within a slot.
+func ticksPerSlot() float64 {
+	return float64(config.Params.SchedulingRate) * config.Params.SlotTime.Seconds()
+}
+
+// capMana limits the mana of an account to ManaCap.
+func capMana(mana float64) float64 {
+	if config.Params.ManaCap > 0 {
+		return math.Min(mana, config.Params.ManaCap)
+	}
+	return mana
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
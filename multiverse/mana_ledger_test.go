@@ -0,0 +1,96 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// newTestManaAccounts sets up the Committed mana ledger of node 0 with 100 mana per node, the genesis of the tangle is
+// currentSlot slots in the past.
+func newTestManaAccounts(t *testing.T, currentSlot int) *manaAccounts {
+	params := *config.Params.CongestionControlSettings
+	slowdownFactor := config.Params.SlowdownFactor
+	t.Cleanup(func() {
+		*config.Params.CongestionControlSettings = params
+		config.Params.SlowdownFactor = slowdownFactor
+	})
+	config.Params.ManaLedgerMode = CommittedManaLedger
+	config.Params.SlotTime, config.Params.MinCommittableAge = time.Second, 4*time.Second
+	config.Params.SlowdownFactor = 1
+
+	tangle := newTestTangle(t, 2)
+	tangle.Storage.Setup(time.Now().Add(-time.Duration(currentSlot) * time.Second))
+
+	accounts := newManaAccounts(tangle)
+	accounts.setup(func(network.PeerID) float64 { return 100 })
+	return accounts
+}
+
+// newTestBurn creates a message of the issuer in the given slot that burns 5 mana.
+func newTestBurn(accounts *manaAccounts, issuer network.PeerID, slot int) *Message {
+	message := newTestMessage(issuer, false)
+	message.IssuanceTime = accounts.tangle.Storage.genesisTime.Add(time.Duration(slot)*time.Second + time.Second/2)
+	message.ManaBurnValue = 5
+	return message
+}
+
+func keepMana(_ network.PeerID, mana float64) float64 { return mana }
+
+func TestCommittedManaLedger(t *testing.T) {
+	// slot 6 is the last committed slot
+	accounts := newTestManaAccounts(t, 10)
+
+	accounts.bookAcceptedMessage(newTestBurn(accounts, 1, 2))
+	accounts.bookAcceptedMessage(newTestBurn(accounts, 1, 8))
+	accounts.increment(nil, keepMana)
+	if mana := accounts.GetNodeAccessMana(1); mana != 95 {
+		t.Fatalf("mana %f after committing one burn", mana)
+	}
+
+	// accepted after its slot was committed, so it is not part of the commitment
+	accounts.bookAcceptedMessage(newTestBurn(accounts, 1, 3))
+	accounts.increment(nil, keepMana)
+	if mana := accounts.GetNodeAccessMana(1); mana != 95 {
+		t.Fatalf("mana %f after accepting a message of a committed slot", mana)
+	}
+
+	// the message of slot 8 is booked in its own slot once that is committed
+	accounts.tangle.Storage.Setup(accounts.tangle.Storage.genesisTime.Add(-3 * time.Second))
+	accounts.increment(nil, keepMana)
+	if mana := accounts.GetNodeAccessMana(1); mana != 90 {
+		t.Fatalf("mana %f after committing slot 8", mana)
+	}
+	if len(accounts.acceptedMessages) != 0 {
+		t.Errorf("%d slots of accepted messages left after committing them", len(accounts.acceptedMessages))
+	}
+}
+
+func TestCommittedManaLedgerPendingBurns(t *testing.T) {
+	accounts := newTestManaAccounts(t, 10)
+	accounts.increment(nil, keepMana)
+
+	message := newTestBurn(accounts, 0, 8)
+	accounts.bookPendingBurn(message)
+	accounts.bookPendingBurn(newTestBurn(accounts, 0, 3))
+	accounts.bookPendingBurn(newTestBurn(accounts, 1, 8))
+	if mana := accounts.GetNodeAccessMana(0); mana != 95 {
+		t.Fatalf("own mana %f with one pending burn", mana)
+	}
+	if mana := accounts.GetNodeAccessMana(1); mana != 100 {
+		t.Fatalf("mana %f of another node with a pending burn", mana)
+	}
+
+	// once slot 8 is committed the burn is taken from the accepted message instead
+	accounts.bookAcceptedMessage(message)
+	accounts.tangle.Storage.Setup(accounts.tangle.Storage.genesisTime.Add(-3 * time.Second))
+	accounts.increment(nil, keepMana)
+	if mana := accounts.GetNodeAccessMana(0); mana != 95 {
+		t.Fatalf("own mana %f after committing the burn", mana)
+	}
+	if len(accounts.pendingBurns) != 0 {
+		t.Errorf("%d slots of pending burns left after committing them", len(accounts.pendingBurns))
+	}
+}
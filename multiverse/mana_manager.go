@@ -0,0 +1,161 @@
+package multiverse
+
+import (
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region ManaManager Interface ////////////////////////////////////////////////////////////////////////////////////////
+
+// ManaManager keeps track of the access mana of all nodes as seen by the local node and decides how much mana the own
+// messages burn.
+type ManaManager interface {
+	Setup()
+	IncrementAccessMana(float64)
+	DecreaseNodeAccessMana(network.PeerID, float64) float64
+	GetNodeAccessMana(network.PeerID) float64
+	BurnValue(time.Time) (float64, bool)
+}
+
+// ManaManagerFactory creates a ManaManager for the given tangle.
+type ManaManagerFactory func(tangle *Tangle) ManaManager
+
+// manaManagerFactories contains the mana managers that can be selected with ManaManagerType.
+var manaManagerFactories = map[string]ManaManagerFactory{
+	"ManaBurn": NewMBManaManager,
+	"ICCA+":    NewICCAManaManager,
+	"Stake":    NewStakeManaManager,
+	"None":     NewNoManaManager,
+}
+
+// RegisterManaManager makes a mana manager available under the given ManaManagerType.
+func RegisterManaManager(name string, factory ManaManagerFactory) {
+	manaManagerFactories[name] = factory
+}
+
+// NewManaManager creates the mana manager configured in ManaManagerType, unknown types do not account any mana.
+func NewManaManager(tangle *Tangle) ManaManager {
+	if factory, exists := manaManagerFactories[config.Params.ManaManagerType]; exists {
+		return factory(tangle)
+	}
+	return NewNoManaManager(tangle)
+}
+
+// rmcEnabled returns true if the configured mana manager burns the RMC, so that the RMC needs to be maintained.
+func rmcEnabled() bool {
+	switch config.Params.ManaManagerType {
+	case "ICCA+":
+		return true
+	case "Stake":
+		return config.Params.SchedulerType != "ManaBurn"
+	default:
+		return false
+	}
+}
+
+// rmcBurnValue burns the RMC of the slot of the issuance time, if the node has enough mana.
+func rmcBurnValue(tangle *Tangle, manaManager ManaManager, issuanceTime time.Time) (float64, bool) {
+	slotIndex := tangle.Storage.SlotIndex(issuanceTime)
+	RMC := tangle.Storage.RMC(slotIndex)
+	return RMC, manaManager.GetNodeAccessMana(tangle.Peer.ID) >= RMC
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ICCAManaManager //////////////////////////////////////////////////////////////////////////////////////////////
+
+// ICCAManaManager generates mana proportionally to the bandwidth of the nodes and burns the RMC of the issuing slot.
+type ICCAManaManager struct {
+	*manaAccounts
+}
+
+func NewICCAManaManager(tangle *Tangle) ManaManager {
+	return &ICCAManaManager{
+		manaAccounts: newManaAccounts(tangle),
+	}
+}
+
+func (m *ICCAManaManager) Setup() {
+	// setup the initial AccessMana when the peer ID is created
+	m.setup(func(network.PeerID) float64 { return 0.0 })
+}
+
+func (m *ICCAManaManager) IncrementAccessMana(schedulingRate float64) {
+	m.increment(m.tickGeneration, func(id network.PeerID, mana float64) float64 {
+		return mana + m.tickGeneration(id, 0)*ticksPerSlot()
+	})
+}
+
+// tickGeneration adds the mana generated by a node within one scheduler tick.
+func (m *ICCAManaManager) tickGeneration(id network.PeerID, mana float64) float64 {
+	totalBandwidth := config.Params.SchedulingRate
+	// every time something is scheduled, we add this much mana in total
+	return mana + 10*m.tangle.BandwidthDistribution.Bandwidth(id)/float64(totalBandwidth)
+}
+
+func (m *ICCAManaManager) BurnValue(issuanceTime time.Time) (float64, bool) {
+	return rmcBurnValue(m.tangle, m, issuanceTime)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MBManaManager ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MBManaManager generates mana proportionally to the consensus weight of the nodes and burns according to the burn
+// policy of the node, bidding against the highest burn in the scheduler.
+type MBManaManager struct {
+	*manaAccounts
+	bidder *BurnBidder
+}
+
+func NewMBManaManager(tangle *Tangle) ManaManager {
+	return &MBManaManager{
+		manaAccounts: newManaAccounts(tangle),
+		bidder:       NewBurnBidder(tangle),
+	}
+}
+
+func (m *MBManaManager) Setup() {
+	// Setup the initial AccessMana when the peer ID is created
+	m.setup(func(network.PeerID) float64 { return config.Params.InitialMana })
+	m.bidder.Setup()
+}
+
+// TODO: schedulingRate is not used
+func (m *MBManaManager) IncrementAccessMana(schedulingRate float64) {
+	m.increment(m.tickGeneration, func(id network.PeerID, mana float64) float64 {
+		return mana + m.tickGeneration(id, 0)*ticksPerSlot()
+	})
+}
+
+// tickGeneration adds the mana generated by a node within one scheduler tick.
+func (m *MBManaManager) tickGeneration(id network.PeerID, mana float64) float64 {
+	totalWeight := config.Params.NodesTotalWeight
+	// every time something is scheduled, we add this much mana in total
+	return mana + 10*float64(m.tangle.WeightDistribution.Weight(id))/float64(totalWeight)
+}
+
+func (m *MBManaManager) BurnValue(issuanceTime time.Time) (burn float64, ok bool) {
+	return m.bidder.BurnValue(m)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NoManaManager ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NoManaManager does not account any mana, every message can be issued without burning.
+type NoManaManager struct{}
+
+func NewNoManaManager(*Tangle) ManaManager {
+	return &NoManaManager{}
+}
+
+func (m *NoManaManager) Setup()                                                 {}
+func (m *NoManaManager) IncrementAccessMana(float64)                            {}
+func (m *NoManaManager) DecreaseNodeAccessMana(network.PeerID, float64) float64 { return 0 }
+func (m *NoManaManager) GetNodeAccessMana(network.PeerID) float64               { return 0 }
+func (m *NoManaManager) BurnValue(time.Time) (float64, bool)                    { return 0, true }
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
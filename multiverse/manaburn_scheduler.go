@@ -15,16 +15,24 @@ type MBScheduler struct {
 	tangle      *Tangle
 	readyQueue  *PriorityQueue
 	nonReadyMap map[MessageID]*Message
-	accessMana  map[network.PeerID]float64
+	// readyWork is the total work of the messages in the ready queue
+	readyWork int
 
 	events *SchedulerEvents
 }
 
-func (s *MBScheduler) Setup() {
-	// Setup the initial AccessMana when the peer ID is created
-	for id := 0; id < config.Params.NodesCount; id++ {
-		s.accessMana[network.PeerID(id)] = config.Params.InitialMana
+func NewMBScheduler(tangle *Tangle) Scheduler {
+	readyHeap := &PriorityQueue{}
+	heap.Init(readyHeap)
+	return &MBScheduler{
+		tangle:      tangle,
+		readyQueue:  readyHeap,
+		nonReadyMap: make(map[MessageID]*Message),
+		events:      newSchedulerEvents(),
 	}
+}
+
+func (s *MBScheduler) Setup() {
 	s.events.MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
 		s.tangle.Peer.GossipNetworkMessage(s.tangle.Storage.Message(messageID))
 		s.updateChildrenReady(messageID)
@@ -32,7 +40,14 @@ func (s *MBScheduler) Setup() {
 		//	s.tangle.Peer.ID, messageID)
 	}))
 	s.events.MessageDropped.Attach(events.NewClosure(func(messageID MessageID) {
-		s.tangle.Storage.MessageMetadata(messageID).SetDropTime(time.Now())
+		if messageMetadata := s.tangle.Storage.MessageMetadata(messageID); messageMetadata != nil {
+			messageMetadata.SetDropTime(time.Now())
+		}
+	}))
+	s.tangle.Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs MessageIDs) {
+		for messageID := range messageIDs {
+			delete(s.nonReadyMap, messageID)
+		}
 	}))
 	s.tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *Message, messageMetadata *MessageMetadata, weight uint64, messageIDCounter int64) {
 		if config.Params.ConfEligible {
@@ -42,56 +57,17 @@ func (s *MBScheduler) Setup() {
 	}))
 }
 
-func (s *MBScheduler) BurnValue(issuanceTime time.Time) (burn float64, ok bool) {
-	peerID := s.tangle.Peer.ID
-	switch policy := config.Params.BurnPolicies[peerID]; BurnPolicyType(policy) {
-	case NoBurn:
-		return 0.0, true
-	case Anxious:
-		burn = s.GetNodeAccessMana(peerID)
-		ok = true
-		return
-	case Greedy1:
-		burn = s.GetMaxManaBurn() + 1.0
-		ok = burn <= s.GetNodeAccessMana(peerID)
-		return
-	case Greedy10:
-		burn = s.GetMaxManaBurn() + 10.0
-		ok = burn <= s.GetNodeAccessMana(peerID)
-		return
-	default:
-		panic("invalid burn policy")
-	}
-}
-
-// TODO: schedulingRate is not used
-func (s *MBScheduler) IncrementAccessMana(schedulingRate float64) {
-	weights := s.tangle.WeightDistribution.Weights()
-	totalWeight := config.Params.NodesTotalWeight
-	// every time something is scheduled, we add this much mana in total\
-	mana := float64(10)
-	for id := range s.accessMana {
-		s.accessMana[id] += mana * float64(weights[id]) / float64(totalWeight)
-	}
-}
-
-func (s *MBScheduler) DecreaseNodeAccessMana(nodeID network.PeerID, manaIncrement float64) (newAccessMana float64) {
-	s.accessMana[nodeID] -= manaIncrement
-	newAccessMana = s.accessMana[nodeID]
-	return newAccessMana
-}
-
 func (s *MBScheduler) ReadyLen() int {
 	return s.readyQueue.Len()
 }
 
-func (s *MBScheduler) NonReadyLen() int {
-	return len(s.nonReadyMap)
+// ReadyWork returns the total work of all ready messages.
+func (s *MBScheduler) ReadyWork() int {
+	return s.readyWork
 }
 
-func (s *MBScheduler) GetNodeAccessMana(nodeID network.PeerID) (mana float64) {
-	mana = s.accessMana[nodeID]
-	return mana
+func (s *MBScheduler) NonReadyLen() int {
+	return len(s.nonReadyMap)
 }
 
 func (s *MBScheduler) Events() *SchedulerEvents {
@@ -116,7 +92,8 @@ func (s *MBScheduler) setReady(messageID MessageID) {
 	// move from non ready queue to ready queue if this child is already enqueued
 	if m, exists := s.nonReadyMap[messageID]; exists {
 		delete(s.nonReadyMap, messageID)
-		heap.Push(s.readyQueue, *m)
+		s.tangle.Storage.MessageMetadata(messageID).SetReadyTime(time.Now())
+		s.push(*m)
 		s.BufferManagement()
 	}
 }
@@ -137,8 +114,13 @@ func (s *MBScheduler) ScheduleMessage() {
 	// pop the Message from top of the priority queue and consume the accessMana
 	if !s.IsEmpty() {
 		m := heap.Pop(s.readyQueue).(Message)
+		s.readyWork -= m.Work
 		if m.Issuer != s.tangle.Peer.ID { // already deducted Mana for own blocks
-			s.DecreaseNodeAccessMana(m.Issuer, m.ManaBurnValue)
+			s.tangle.ManaManager.DecreaseNodeAccessMana(m.Issuer, m.ManaBurnValue)
+		}
+		// the message was pruned while it was waiting in the queue
+		if s.tangle.Storage.IsPruned(m.ID) {
+			return
 		}
 		s.tangle.Storage.MessageMetadata(m.ID).SetScheduleTime(time.Now())
 		s.updateChildrenReady(m.ID)
@@ -147,13 +129,19 @@ func (s *MBScheduler) ScheduleMessage() {
 }
 
 func (s *MBScheduler) EnqueueMessage(messageID MessageID) {
-	s.tangle.Storage.MessageMetadata(messageID).SetEnqueueTime(time.Now())
+	enqueueTime := time.Now()
+	s.tangle.Storage.MessageMetadata(messageID).SetEnqueueTime(enqueueTime)
 	// Check if the message is ready to decide which queue to append to
 	if s.tangle.Storage.isReady(messageID) {
 		//log.Debugf("Ready Message Enqueued")
 		s.tangle.Storage.MessageMetadata(messageID).SetReady()
+		s.tangle.Storage.MessageMetadata(messageID).SetReadyTime(enqueueTime)
 		m := *s.tangle.Storage.Message(messageID)
-		heap.Push(s.readyQueue, m)
+		if earlyDrop(s.readyWork) {
+			s.events.MessageDropped.Trigger(messageID)
+		} else {
+			s.push(m)
+		}
 	} else {
 		//log.Debug("Not Ready Message Enqueued")
 		s.tangle.Storage.MessageMetadata(messageID).SetReady()
@@ -163,21 +151,40 @@ func (s *MBScheduler) EnqueueMessage(messageID MessageID) {
 	s.BufferManagement()
 }
 
+// BufferManagement drops messages according to the DropPolicy until the work of the ready messages fits into
+// MaxBuffer. By default the message with the lowest burn value is dropped.
 func (s *MBScheduler) BufferManagement() {
-	for s.readyQueue.Len() > config.Params.MaxBuffer {
-		tail := s.readyQueue.tail()
-		heap.Remove(s.readyQueue, tail) // remove the lowest burn value/ issuance time
+	for s.readyWork > config.Params.MaxBuffer {
+		work := issuerWork(*s.readyQueue)
+		buffered := make([]bufferedMessage, 0, s.readyQueue.Len())
+		for i := range *s.readyQueue {
+			m := &(*s.readyQueue)[i]
+			buffered = append(buffered, bufferedMessage{message: m, issuerLoad: float64(work[m.Issuer])})
+		}
+		dropped, ok := selectDroppedMessage(dropPolicy(DropByBurn), buffered)
+		if !ok {
+			return
+		}
+		m := heap.Remove(s.readyQueue, dropped).(Message)
+		s.readyWork -= m.Work
+		s.events.MessageDropped.Trigger(m.ID)
 	}
 }
 
+// push adds the message to the ready queue.
+func (s *MBScheduler) push(m Message) {
+	heap.Push(s.readyQueue, m)
+	s.readyWork += m.Work
+}
+
 func (s *MBScheduler) IssuerQueueLen(issuer network.PeerID) int {
 	return 0
 }
 
-func (s *MBScheduler) Deficit(issuer network.PeerID) float64 {
-	return 0.0
+func (s *MBScheduler) IssuerQueueWork(issuer network.PeerID) int {
+	return 0
 }
 
-func (s *MBScheduler) RateSetter() bool {
-	return true
+func (s *MBScheduler) Deficit(issuer network.PeerID) float64 {
+	return 0.0
 }
@@ -0,0 +1,42 @@
+package multiverse
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+func TestMBSchedulerWorkBuffer(t *testing.T) {
+	defer func(schedulerType string, maxBuffer int, dropPolicy string) {
+		config.Params.SchedulerType, config.Params.MaxBuffer, config.Params.DropPolicy = schedulerType, maxBuffer, dropPolicy
+	}(config.Params.SchedulerType, config.Params.MaxBuffer, config.Params.DropPolicy)
+	config.Params.SchedulerType, config.Params.MaxBuffer, config.Params.DropPolicy = "ManaBurn", 4, ""
+
+	tangle := newTestTangle(t, 3)
+	scheduler := tangle.Scheduler.(*MBScheduler)
+	dropped := NewMessageIDs()
+	scheduler.Events().MessageDropped.Attach(events.NewClosure(func(messageID MessageID) { dropped.Add(messageID) }))
+
+	// three messages fit into the buffer by count, but their work exceeds it, so the one with the lowest burn is dropped
+	messages := make([]*Message, 0, 3)
+	for i := 0; i < 3; i++ {
+		message := newTestMessage(1, false)
+		message.Work = 2
+		message.ManaBurnValue = float64(i + 1)
+		messages = append(messages, message)
+		tangle.ProcessMessage(message)
+	}
+
+	if _, lowestDropped := dropped[messages[0].ID]; len(dropped) != 1 || !lowestDropped {
+		t.Fatalf("expected only the message with the lowest burn to be dropped, got %v", dropped)
+	}
+	if scheduler.ReadyLen() != 2 || scheduler.ReadyWork() != 4 {
+		t.Fatalf("expected 2 ready messages with 4 work units, got %d with %d", scheduler.ReadyLen(), scheduler.ReadyWork())
+	}
+
+	scheduler.ScheduleMessage()
+	if scheduler.ReadyWork() != 2 {
+		t.Fatalf("expected 2 ready work units after scheduling, got %d", scheduler.ReadyWork())
+	}
+}
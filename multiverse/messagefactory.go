@@ -1,8 +1,15 @@
 package multiverse
 
 import (
+	"math"
+	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
 )
 
 // region MessageFactory ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -11,6 +18,17 @@ type MessageFactory struct {
 	tangle         *Tangle
 	sequenceNumber uint64
 	numberOfNodes  uint64
+
+	// nextWork is the work of the next data message, drawn in advance so that the rate setter can take it into account
+	nextWork      int
+	nextWorkMutex sync.Mutex
+
+	// issuingRate is an exponentially decaying estimate of the data messages issued per second at lastIssuanceTime
+	issuingRate      float64
+	lastIssuanceTime time.Time
+
+	// dataScheduled is set to 1 when a data message is scheduled and reset by the next validation block
+	dataScheduled int32
 }
 
 func NewMessageFactory(tangle *Tangle, numberOfNodes uint64) (messageFactory *MessageFactory) {
@@ -20,30 +38,147 @@ func NewMessageFactory(tangle *Tangle, numberOfNodes uint64) (messageFactory *Me
 	}
 }
 
+func (m *MessageFactory) Setup() {
+	m.tangle.Scheduler.Events().MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		if message := m.tangle.Storage.Message(messageID); message != nil && !message.Validation {
+			atomic.StoreInt32(&m.dataScheduled, 1)
+		}
+	}))
+}
+
+// ValidationNeeded returns false if AdaptiveValidatorIssuance is enabled and no data message was scheduled since the
+// last validation block of the node, so the validation block would not approve any new data.
+func (m *MessageFactory) ValidationNeeded() bool {
+	return !config.Params.AdaptiveValidatorIssuance || atomic.LoadInt32(&m.dataScheduled) == 1
+}
+
 func (m *MessageFactory) CreateMessage(validation bool, payload Color) (*Message, bool) {
+	if burn, ok := m.tangle.ManaManager.BurnValue(time.Now()); ok {
+		m.tangle.ManaManager.DecreaseNodeAccessMana(m.tangle.Peer.ID, burn) // decrease the nodes own Mana when the message is created
+		return m.CreateMessageWithBurn(validation, payload, burn), ok
+	} else {
+		return nil, false
+	}
+}
+
+// CreateMessageWithBurn creates a message that burns the given mana, regardless of the mana of the node and without
+// decreasing it.
+func (m *MessageFactory) CreateMessageWithBurn(validation bool, payload Color, burn float64) *Message {
 	strongParents, weakParents := m.tangle.TipManager.Tips(validation)
 	issuanceTime := time.Now()
-	if burn, ok := m.tangle.Scheduler.BurnValue(issuanceTime); ok {
-		m.tangle.Scheduler.DecreaseNodeAccessMana(m.tangle.Peer.ID, burn) // decrease the nodes own Mana when the message is created
-		message := &Message{
-			ID:             NewMessageID(),
-			Validation:     validation,
-			StrongParents:  strongParents,
-			WeakParents:    weakParents,
-			SequenceNumber: atomic.AddUint64(&m.sequenceNumber, 1),
-			Issuer:         m.tangle.Peer.ID,
-			Payload:        payload,
-			IssuanceTime:   issuanceTime,
-			ManaBurnValue:  burn,
-		}
-		return message, ok
+	message := &Message{
+		ID:             NewMessageID(),
+		Validation:     validation,
+		StrongParents:  strongParents,
+		WeakParents:    weakParents,
+		SequenceNumber: atomic.AddUint64(&m.sequenceNumber, 1),
+		Issuer:         m.tangle.Peer.ID,
+		Payload:        payload,
+		IssuanceTime:   issuanceTime,
+		ManaBurnValue:  burn,
+		Work:           m.Work(validation, payload),
+	}
+	if validation {
+		atomic.StoreInt32(&m.dataScheduled, 0)
 	} else {
-		return nil, false
+		message.ManaTransfer = m.manaTransfer()
+	}
+	if !validation {
+		m.issuingRate = m.IssuingRate() + 1/issuingRateWindow().Seconds()
+		m.lastIssuanceTime = issuanceTime
 	}
+	return message
 }
 
 func (m *MessageFactory) SequenceNumber() uint64 {
 	return atomic.AddUint64(&m.sequenceNumber, 1)
 }
 
+// IssuingRate returns the current rate of the own data messages in messages per second, averaged exponentially over
+// the last second.
+func (m *MessageFactory) IssuingRate() float64 {
+	return m.issuingRate * math.Exp(-time.Since(m.lastIssuanceTime).Seconds()/issuingRateWindow().Seconds())
+}
+
+// manaTransfer returns a transfer of ManaTransferFraction of the own mana to a random other node with probability
+// ManaTransferProbability, nil otherwise.
+func (m *MessageFactory) manaTransfer() *ManaTransfer {
+	if m.numberOfNodes < 2 || rand.Float64() >= config.Params.ManaTransferProbability {
+		return nil
+	}
+	amount := config.Params.ManaTransferFraction * m.tangle.ManaManager.GetNodeAccessMana(m.tangle.Peer.ID)
+	if amount <= 0 {
+		return nil
+	}
+	receiver := network.PeerID(rand.Intn(int(m.numberOfNodes) - 1))
+	if receiver >= m.tangle.Peer.ID {
+		receiver++
+	}
+	return &ManaTransfer{
+		Receiver: receiver,
+		Amount:   amount,
+	}
+}
+
+// NextWork returns the work of the next data message issued by the node.
+func (m *MessageFactory) NextWork() int {
+	m.nextWorkMutex.Lock()
+	defer m.nextWorkMutex.Unlock()
+	if m.nextWork == 0 {
+		m.nextWork = randomWork()
+	}
+	return m.nextWork
+}
+
+// Work returns the work of a new message, which depends on its type. The work of a data message is drawn anew for the
+// next message afterwards.
+func (m *MessageFactory) Work(validation bool, payload Color) (work int) {
+	switch {
+	case validation:
+		return config.Params.ValidationWork
+	case payload != UndefinedColor && config.Params.ColoredWork > 0:
+		return config.Params.ColoredWork
+	default:
+		work = m.NextWork()
+		m.nextWorkMutex.Lock()
+		m.nextWork = 0
+		m.nextWorkMutex.Unlock()
+		return
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Work /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// issuingRateWindow returns the time window of the issuing rate estimate.
+func issuingRateWindow() time.Duration {
+	return time.Second * time.Duration(config.Params.SlowdownFactor)
+}
+
+// randomWork draws the work of a data message from the configured WorkDistribution.
+func randomWork() int {
+	switch config.Params.WorkDistribution {
+	case "Uniform":
+		return config.Params.MinWork + rand.Intn(config.Params.MaxWork-config.Params.MinWork+1)
+	default:
+		return config.Params.MinWork
+	}
+}
+
+// MaxMessageWork returns the largest work a message can have.
+func MaxMessageWork() (maxWork int) {
+	maxWork = config.Params.MinWork
+	if config.Params.WorkDistribution == "Uniform" && config.Params.MaxWork > maxWork {
+		maxWork = config.Params.MaxWork
+	}
+	if config.Params.ValidationWork > maxWork {
+		maxWork = config.Params.ValidationWork
+	}
+	if config.Params.ColoredWork > maxWork {
+		maxWork = config.Params.ColoredWork
+	}
+	return
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,130 @@
+package multiverse
+
+import (
+	"sync"
+)
+
+// region MessageStore /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sharedStore is the message store used by the storages of all nodes if SharedMessageStore is enabled.
+var sharedStore = NewMessageStore()
+
+// MessageStore is an immutable index of messages and their children that is shared by the storages of all nodes, so
+// every message and every child reference is only kept once for the whole simulation. The store counts how many nodes
+// reference a message and forgets it once the last node released it.
+type MessageStore struct {
+	messages       map[MessageID]*Message
+	strongChildren map[MessageID]MessageIDs
+	weakChildren   map[MessageID]MessageIDs
+	references     map[MessageID]int
+
+	mutex sync.RWMutex
+}
+
+func NewMessageStore() *MessageStore {
+	return &MessageStore{
+		messages:       make(map[MessageID]*Message),
+		strongChildren: make(map[MessageID]MessageIDs),
+		weakChildren:   make(map[MessageID]MessageIDs),
+		references:     make(map[MessageID]int),
+	}
+}
+
+// Reference adds the message to the store if it is not known yet and registers a new node referencing it.
+func (m *MessageStore) Reference(message *Message) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.references[message.ID]++
+	if m.references[message.ID] > 1 {
+		return
+	}
+
+	m.messages[message.ID] = message
+	storeChildReferences(message.ID, m.strongChildren, message.StrongParents)
+	storeChildReferences(message.ID, m.weakChildren, message.WeakParents)
+}
+
+// Release unregisters a node referencing the message and removes the message once no node references it anymore.
+func (m *MessageStore) Release(messageID MessageID) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.references[messageID]--; m.references[messageID] > 0 {
+		return
+	}
+
+	if message, exists := m.messages[messageID]; exists {
+		removeChildReferences(messageID, m.strongChildren, message.StrongParents)
+		removeChildReferences(messageID, m.weakChildren, message.WeakParents)
+	}
+	delete(m.messages, messageID)
+	delete(m.strongChildren, messageID)
+	delete(m.weakChildren, messageID)
+	delete(m.references, messageID)
+}
+
+func (m *MessageStore) Message(messageID MessageID) *Message {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.messages[messageID]
+}
+
+// StrongChildren returns the strong children of the message for which the filter returns true.
+func (m *MessageStore) StrongChildren(messageID MessageID, filter func(MessageID) bool) MessageIDs {
+	return m.children(m.strongChildren, messageID, filter)
+}
+
+// WeakChildren returns the weak children of the message for which the filter returns true.
+func (m *MessageStore) WeakChildren(messageID MessageID, filter func(MessageID) bool) MessageIDs {
+	return m.children(m.weakChildren, messageID, filter)
+}
+
+// MessagesCount returns the number of messages referenced by at least one node.
+func (m *MessageStore) MessagesCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.messages)
+}
+
+func (m *MessageStore) children(childReferenceDB map[MessageID]MessageIDs, messageID MessageID, filter func(MessageID) bool) MessageIDs {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	children := NewMessageIDs()
+	for childID := range childReferenceDB[messageID] {
+		if filter(childID) {
+			children.Add(childID)
+		}
+	}
+	return children
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+func storeChildReferences(messageID MessageID, childReferenceDB map[MessageID]MessageIDs, parents MessageIDs) {
+	for parent := range parents {
+		if _, exists := childReferenceDB[parent]; !exists {
+			childReferenceDB[parent] = NewMessageIDs()
+		}
+
+		childReferenceDB[parent].Add(messageID)
+	}
+}
+
+func removeChildReferences(messageID MessageID, childReferenceDB map[MessageID]MessageIDs, parents MessageIDs) {
+	for parent := range parents {
+		if children, exists := childReferenceDB[parent]; exists {
+			delete(children, messageID)
+			if len(children) == 0 {
+				delete(childReferenceDB, parent)
+			}
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -27,6 +27,24 @@ type Message struct {
 	Payload        Color
 	IssuanceTime   time.Time
 	ManaBurnValue  float64
+	// Work is the amount of scheduler work units needed to schedule the message
+	Work int
+	// ManaTransfer is the mana the issuer transfers with the message, nil if it does not transfer any mana
+	ManaTransfer *ManaTransfer
+}
+
+// ScheduledWork returns the work the scheduler charges for the message, messages without work count as one unit.
+func (m *Message) ScheduledWork() int {
+	if m.Work < 1 {
+		return 1
+	}
+	return m.Work
+}
+
+// ManaTransfer moves mana from the issuer of a message to the receiver.
+type ManaTransfer struct {
+	Receiver network.PeerID
+	Amount   float64
 }
 
 // endregion Message ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -38,30 +56,36 @@ type MessageMetadata struct {
 	solid            bool
 	ready            bool
 	inheritedColor   Color
-	weightSlice      []byte
+	approvers        validatorBits
 	weight           uint64
-	confirmationTime time.Time
-	orphanTime       time.Time
-	arrivalTime      time.Time
-	enqueueTime      time.Time
-	scheduleTime     time.Time
-	dropTime         time.Time
+	acceptanceTime   compactTime
+	confirmationTime compactTime
+	orphanTime       compactTime
+	arrivalTime      compactTime
+	enqueueTime      compactTime
+	readyTime        compactTime
+	scheduleTime     compactTime
+	dropTime         compactTime
 }
 
 func (m *MessageMetadata) ArrivalTime() time.Time {
-	return m.arrivalTime
+	return m.arrivalTime.Time()
 }
 
-func (m *MessageMetadata) WeightByte(index int) byte {
-	return m.weightSlice[index]
+// ApprovedBy returns true if the validator with the given index of the ApprovalManager approves the message.
+func (m *MessageMetadata) ApprovedBy(validatorIndex int) bool {
+	return m.approvers.has(validatorIndex)
 }
 
-func (m *MessageMetadata) SetWeightByte(index int, weight byte) {
-	m.weightSlice[index] = weight
+// AddApprover marks the validator with the given index as an approver of the message, it returns false if the
+// validator already approved it.
+func (m *MessageMetadata) AddApprover(validatorIndex int) bool {
+	return m.approvers.add(validatorIndex)
 }
 
-func (m *MessageMetadata) SetWeightSlice(weightSlice []byte) {
-	m.weightSlice = weightSlice
+// ReleaseApprovers drops the approvers of a message whose weight does not change anymore.
+func (m *MessageMetadata) ReleaseApprovers() {
+	m.approvers = nil
 }
 
 func (m *MessageMetadata) Weight() uint64 {
@@ -76,28 +100,61 @@ func (m *MessageMetadata) SetWeight(weight uint64) {
 	m.weight = weight
 }
 
+func (m *MessageMetadata) AcceptanceTime() time.Time {
+	return m.acceptanceTime.Time()
+}
+
+func (m *MessageMetadata) SetAcceptanceTime(acceptanceTime time.Time) {
+	m.acceptanceTime = newCompactTime(acceptanceTime)
+}
+
 func (m *MessageMetadata) ConfirmationTime() time.Time {
-	return m.confirmationTime
+	return m.confirmationTime.Time()
 }
 
 func (m *MessageMetadata) SetConfirmationTime(confirmationTime time.Time) {
-	m.confirmationTime = confirmationTime
+	m.confirmationTime = newCompactTime(confirmationTime)
 }
 
 func (m *MessageMetadata) SetOrphanTime(orphanTime time.Time) {
-	m.orphanTime = orphanTime
+	m.orphanTime = newCompactTime(orphanTime)
+}
+
+func (m *MessageMetadata) OrphanTime() time.Time {
+	return m.orphanTime.Time()
 }
 
 func (m *MessageMetadata) SetEnqueueTime(enqueueTime time.Time) {
-	m.enqueueTime = enqueueTime
+	m.enqueueTime = newCompactTime(enqueueTime)
+}
+
+func (m *MessageMetadata) EnqueueTime() time.Time {
+	return m.enqueueTime.Time()
+}
+
+// SetReadyTime sets the time the enqueued message entered the ready queue of the scheduler.
+func (m *MessageMetadata) SetReadyTime(readyTime time.Time) {
+	m.readyTime = newCompactTime(readyTime)
+}
+
+func (m *MessageMetadata) ReadyTime() time.Time {
+	return m.readyTime.Time()
+}
+
+func (m *MessageMetadata) ScheduleTime() time.Time {
+	return m.scheduleTime.Time()
 }
 
 func (m *MessageMetadata) SetScheduleTime(scheduleTime time.Time) {
-	m.scheduleTime = scheduleTime
+	m.scheduleTime = newCompactTime(scheduleTime)
 }
 
 func (m *MessageMetadata) SetDropTime(dropTime time.Time) {
-	m.dropTime = dropTime
+	m.dropTime = newCompactTime(dropTime)
+}
+
+func (m *MessageMetadata) DropTime() time.Time {
+	return m.dropTime.Time()
 }
 
 func (m *MessageMetadata) ID() (messageID MessageID) {
@@ -116,6 +173,10 @@ func (m *MessageMetadata) Scheduled() bool {
 	return !m.scheduleTime.IsZero()
 }
 
+func (m *MessageMetadata) Accepted() bool {
+	return !m.acceptanceTime.IsZero()
+}
+
 func (m *MessageMetadata) Confirmed() bool {
 	return !m.confirmationTime.IsZero()
 }
@@ -160,6 +221,61 @@ func (m *MessageMetadata) InheritedColor() (color Color) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region compactTime //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// compactTime stores a point in time as nanoseconds since the unix epoch, which takes a third of the memory of a
+// time.Time and matters as every node keeps several timestamps for every message. The zero value means unset.
+type compactTime int64
+
+func newCompactTime(t time.Time) compactTime {
+	if t.IsZero() {
+		return 0
+	}
+	return compactTime(t.UnixNano())
+}
+
+func (c compactTime) Time() time.Time {
+	if c == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(c))
+}
+
+func (c compactTime) IsZero() bool {
+	return c == 0
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region validatorBits ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// validatorBits is a bitset with one bit per validator index of the ApprovalManager, so its size grows with the number
+// of validators that issued validation blocks instead of with the number of nodes. It is allocated lazily, so messages
+// that never receive any approval weight do not pay for it.
+type validatorBits []uint64
+
+func (v validatorBits) has(index int) bool {
+	word := index / 64
+	return word < len(v) && v[word]&(1<<(index%64)) != 0
+}
+
+// add sets the bit of the index and returns false if it was already set.
+func (v *validatorBits) add(index int) bool {
+	word := index / 64
+	if word >= len(*v) {
+		grown := make(validatorBits, word+1)
+		copy(grown, *v)
+		*v = grown
+	}
+	if (*v)[word]&(1<<(index%64)) != 0 {
+		return false
+	}
+	(*v)[word] |= 1 << (index % 64)
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region MessageRequest ///////////////////////////////////////////////////////////////////////////////////////////////
 
 type MessageRequest struct {
@@ -16,6 +16,8 @@ type NodeInterface interface {
 	Peer() *network.Peer
 	Tangle() *Tangle
 	IssuePayload(payload Color)
+	IssueValidation()
+	AssignColor(color Color)
 }
 
 type Node struct {
@@ -42,29 +44,69 @@ func (n *Node) Setup(peer *network.Peer, weightDistribution *network.ConsensusWe
 
 	n.peer = peer
 	n.tangle.Setup(peer, weightDistribution, bandwidthDistribution, genesisTime)
+	if len(n.tangle.Hooks.GossipFilters) > 0 {
+		n.peer.GossipFilter = n.tangle.Hooks.gossipAllowed
+	}
 	n.tangle.Requester.Events.Request.Attach(events.NewClosure(func(messageID MessageID) {
 		n.peer.GossipNetworkMessage(&MessageRequest{MessageID: messageID, Issuer: n.peer.ID})
 	}))
 	n.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(func(messageID MessageID) {
+		if len(n.tangle.Hooks.EnqueueFilters) > 0 && !n.tangle.Hooks.enqueueAllowed(n.tangle.Storage.Message(messageID)) {
+			return
+		}
 		// Push the message to the scheduling buffer
 		n.tangle.Scheduler.EnqueueMessage(messageID)
 	}))
 }
 
+// AssignColor informs the adversary behaviors of the node about the color of the double spend it issues, honest nodes
+// ignore it.
+func (n *Node) AssignColor(color Color) {
+	for _, colorAssigned := range n.tangle.Hooks.ColorAssigned {
+		colorAssigned(color)
+	}
+}
+
 // IssuePayload sends the Color to the socket for creating a new Message
 func (n *Node) IssuePayload(payload Color) {
 	n.peer.Socket <- payload
 }
 
+// IssueValidation creates a validation block, the adversary behaviors of the node can replace it.
+func (n *Node) IssueValidation() {
+	if n.tangle.Hooks.validationHandled() {
+		return
+	}
+	if message, ok := n.tangle.MessageFactory.CreateMessage(true, UndefinedColor); ok {
+		n.tangle.ProcessMessage(message)
+	}
+}
+
 func (n *Node) HandleNetworkMessage(networkMessage interface{}) {
 	switch receivedNetworkMessage := networkMessage.(type) {
 	case *MessageRequest:
+		if n.tangle.Hooks.requestHandled(receivedNetworkMessage) {
+			return
+		}
 		if requestedMessage := n.tangle.Storage.Message(receivedNetworkMessage.MessageID); requestedMessage != nil {
 			n.peer.Neighbors[receivedNetworkMessage.Issuer].Send(requestedMessage)
 		}
 	case *Message:
+		if !n.tangle.TimestampValidator.Valid(receivedNetworkMessage) {
+			return
+		}
 		n.tangle.ProcessMessage(receivedNetworkMessage)
+	case *ExportRequest:
+		err := n.tangle.Exporter.Export(receivedNetworkMessage)
+		if receivedNetworkMessage.Done != nil {
+			receivedNetworkMessage.Done <- err
+		} else if err != nil {
+			log.Error("Failed to export the tangle: ", err)
+		}
 	case Color:
+		if n.tangle.Hooks.issuanceHandled(receivedNetworkMessage) {
+			return
+		}
 		// create own message
 		if message, ok := n.tangle.MessageFactory.CreateMessage(false, receivedNetworkMessage); ok {
 			n.tangle.ProcessMessage(message)
@@ -0,0 +1,91 @@
+package multiverse
+
+import (
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region NodeHooks ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeHooks are the points where adversary behaviors change the behavior of an honest node. Every hook is a list, so
+// independent behaviors can be combined on the same node. The opinion policy is changed by replacing the
+// OpinionManager of the Tangle before the node is set up.
+type NodeHooks struct {
+	// ColorAssigned is called with the color of the double spend issued by the node.
+	ColorAssigned []func(color Color)
+	// TipSelectors change the strong parents selected by the TipManager, in the order of the list.
+	TipSelectors []func(strongTips MessageIDs, validation bool) MessageIDs
+	// GossipFilters decide whether a network message is sent to a neighbor, all filters have to agree.
+	GossipFilters []func(neighbor network.PeerID, networkMessage interface{}) bool
+	// EnqueueFilters decide whether a booked message is enqueued in the scheduler, all filters have to agree. Messages
+	// that are filtered out are never scheduled, so they are neither gossiped nor selected as tips.
+	EnqueueFilters []func(message *Message) bool
+	// IssuanceHandlers are called for every payload the node issues, the first handler that returns true replaces
+	// the honest creation of the message.
+	IssuanceHandlers []func(payload Color) (handled bool)
+	// ValidationHandlers are called for every validation block the node issues, the first handler that returns true
+	// replaces the honest creation of the block.
+	ValidationHandlers []func() (handled bool)
+	// RequestHandlers are called for every request of a neighbor, the first handler that returns true replaces the
+	// honest answer.
+	RequestHandlers []func(request *MessageRequest) (handled bool)
+	// RateSetterBypassed makes the node issue at its full issuing rate regardless of the RateSetter.
+	RateSetterBypassed bool
+}
+
+func NewNodeHooks() *NodeHooks {
+	return &NodeHooks{}
+}
+
+func (h *NodeHooks) selectTips(strongTips MessageIDs, validation bool) MessageIDs {
+	for _, tipSelector := range h.TipSelectors {
+		strongTips = tipSelector(strongTips, validation)
+	}
+	return strongTips
+}
+
+func (h *NodeHooks) gossipAllowed(neighbor network.PeerID, networkMessage interface{}) bool {
+	for _, gossipFilter := range h.GossipFilters {
+		if !gossipFilter(neighbor, networkMessage) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *NodeHooks) enqueueAllowed(message *Message) bool {
+	for _, enqueueFilter := range h.EnqueueFilters {
+		if !enqueueFilter(message) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *NodeHooks) issuanceHandled(payload Color) bool {
+	for _, issuanceHandler := range h.IssuanceHandlers {
+		if issuanceHandler(payload) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *NodeHooks) validationHandled() bool {
+	for _, validationHandler := range h.ValidationHandlers {
+		if validationHandler() {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *NodeHooks) requestHandled(request *MessageRequest) bool {
+	for _, requestHandler := range h.RequestHandlers {
+		if requestHandler(request) {
+			return true
+		}
+	}
+	return false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,6 +1,8 @@
 package multiverse
 
 import (
+	"sync"
+
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/multivers-simulation/config"
 	"github.com/iotaledger/multivers-simulation/network"
@@ -24,8 +26,10 @@ type OpinionManagerInterface interface {
 type OpinionManager struct {
 	events *OpinionManagerEvents
 
-	tangle          *Tangle
+	tangle *Tangle
+	// ownOpinion is only written by the node, ownOpinionMutex guards it against the reads of other nodes
 	ownOpinion      Color
+	ownOpinionMutex sync.RWMutex
 	peerOpinions    map[network.PeerID]*Opinion
 	approvalWeights map[Color]uint64
 	colorConfirmed  bool
@@ -81,6 +85,16 @@ func (o *OpinionManager) UpdateWeights(messageID MessageID) (updated bool) {
 	message := o.tangle.Storage.Message(messageID)
 	messageMetadata := o.tangle.Storage.MessageMetadata(messageID)
 
+	// this is the authoritative tracking of the equivocations, it sees every booked message, the ApprovalManager only
+	// tracks the validation blocks earlier to keep the weight of an equivocation from being counted
+	o.tangle.EquivocationDetector.Track(message)
+	if o.tangle.EquivocationDetector.Ignored(message.Issuer) {
+		if config.Params.EquivocationPolicy == EquivocationSlash {
+			return o.slash(message.Issuer)
+		}
+		return
+	}
+
 	if messageMetadata.InheritedColor() == UndefinedColor {
 		return
 	}
@@ -123,7 +137,24 @@ func (o *OpinionManager) UpdateWeights(messageID MessageID) (updated bool) {
 	return
 }
 
+// slash removes the last vote of the equivocator from the approval weights, its later votes are ignored.
+func (o *OpinionManager) slash(equivocator network.PeerID) (updated bool) {
+	lastOpinion, exist := o.peerOpinions[equivocator]
+	if !exist || lastOpinion.Color == UndefinedColor {
+		return
+	}
+
+	weight := o.tangle.WeightDistribution.Weight(equivocator)
+	o.approvalWeights[lastOpinion.Color] -= weight
+	o.events.ApprovalWeightUpdated.Trigger(lastOpinion.Color, int64(-weight))
+	lastOpinion.Color = UndefinedColor
+	updated = true
+	return
+}
+
 func (o *OpinionManager) Opinion() Color {
+	o.ownOpinionMutex.RLock()
+	defer o.ownOpinionMutex.RUnlock()
 	return o.ownOpinion
 }
 
@@ -131,6 +162,12 @@ func (o *OpinionManager) SetOpinion(opinion Color) {
 	if oldOpinion := o.ownOpinion; oldOpinion != opinion {
 		o.events.OpinionChanged.Trigger(oldOpinion, opinion, int64(o.Tangle().WeightDistribution.Weight(o.Tangle().Peer.ID)), o.tangle.Peer.ID)
 	}
+	o.setOwnOpinion(opinion)
+}
+
+func (o *OpinionManager) setOwnOpinion(opinion Color) {
+	o.ownOpinionMutex.Lock()
+	defer o.ownOpinionMutex.Unlock()
 	o.ownOpinion = opinion
 }
 
@@ -153,7 +190,7 @@ func (o *OpinionManager) WeightsUpdated() {
 	maxOpinion := getMaxOpinion(o.approvalWeights)
 	oldOpinion := o.ownOpinion
 	if maxOpinion != oldOpinion {
-		o.ownOpinion = maxOpinion
+		o.setOwnOpinion(maxOpinion)
 		o.Events().OpinionChanged.Trigger(oldOpinion, maxOpinion, int64(o.tangle.WeightDistribution.Weight(o.tangle.Peer.ID)))
 	}
 	o.UpdateConfirmation(oldOpinion, maxOpinion)
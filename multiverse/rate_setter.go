@@ -0,0 +1,239 @@
+package multiverse
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+// region RateSetter Interface /////////////////////////////////////////////////////////////////////////////////////////
+
+// RateSetter decides whether the node is allowed to issue a new message right now.
+type RateSetter interface {
+	Setup()
+	CanIssue() bool
+}
+
+// PacedRateSetter is a RateSetter that paces the issuance itself. The node offers it a new message at every scheduler
+// tick instead of at the pace of its bandwidth, so its rate can also grow beyond the bandwidth of the node.
+type PacedRateSetter interface {
+	RateSetter
+	// Rate returns the current issuing rate in work units per second.
+	Rate() float64
+}
+
+// RateSetterFactory creates a RateSetter for the given tangle.
+type RateSetterFactory func(tangle *Tangle) RateSetter
+
+// rateSetterFactories contains the rate setters that can be selected with RateSetterType.
+var rateSetterFactories = map[string]RateSetterFactory{
+	"ManaBurn":    NewNoRateSetter,
+	"ICCA+":       NewICCARateSetter,
+	"AIMD":        NewAIMDRateSetter,
+	"TokenBucket": NewTokenBucketRateSetter,
+	"None":        NewNoRateSetter,
+}
+
+// RegisterRateSetter makes a rate setter available under the given RateSetterType.
+func RegisterRateSetter(name string, factory RateSetterFactory) {
+	rateSetterFactories[name] = factory
+}
+
+// NewRateSetter creates the rate setter configured in RateSetterType, unknown types never hold back messages.
+func NewRateSetter(tangle *Tangle) RateSetter {
+	if factory, exists := rateSetterFactories[config.Params.RateSetterType]; exists {
+		return factory(tangle)
+	}
+	return NewNoRateSetter(tangle)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ICCARateSetter ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ICCARateSetter only issues if the own deficit in the scheduler covers the work of the own queue plus the work of the
+// new message.
+type ICCARateSetter struct {
+	tangle *Tangle
+}
+
+func NewICCARateSetter(tangle *Tangle) RateSetter {
+	return &ICCARateSetter{
+		tangle: tangle,
+	}
+}
+
+func (r *ICCARateSetter) Setup() {}
+
+func (r *ICCARateSetter) CanIssue() bool {
+	if r.tangle.Scheduler.ReadyLen() == 0 || config.Params.BurnPolicies[r.tangle.Peer.ID] == 0 {
+		return true
+	}
+	queueWork := r.tangle.Scheduler.IssuerQueueWork(r.tangle.Peer.ID)
+	return int(r.tangle.Scheduler.Deficit(r.tangle.Peer.ID)) >= queueWork+r.tangle.MessageFactory.NextWork()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AIMDRateSetter ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// AIMDRateSetter limits the own issuing rate with an additive increase, multiplicative decrease controller. The rate is
+// given in work units per second and grows with every own message that gets scheduled. It is cut by AIMDDecrease
+// whenever the local scheduler signals congestion: an own message is dropped, the own queue holds more than
+// AIMDQueueThreshold work or the RMC increased. After a decrease the rate is not decreased again for AIMDHoldTime.
+type AIMDRateSetter struct {
+	tangle *Tangle
+
+	rate         float64
+	nextIssue    time.Time
+	lastDecrease time.Time
+	lastRMC      float64
+
+	mutex sync.Mutex
+}
+
+func NewAIMDRateSetter(tangle *Tangle) RateSetter {
+	return &AIMDRateSetter{
+		tangle: tangle,
+	}
+}
+
+func (r *AIMDRateSetter) Setup() {
+	// start with the fair share of the node
+	r.rate = math.Max(float64(r.tangle.BandwidthDistribution.Bandwidth(r.tangle.Peer.ID)), config.Params.AIMDMinRate)
+
+	r.tangle.Scheduler.Events().MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		if r.ownDataMessage(messageID) {
+			r.increase(r.tangle.Storage.Message(messageID).ScheduledWork())
+		}
+	}))
+	r.tangle.Scheduler.Events().MessageDropped.Attach(events.NewClosure(func(messageID MessageID) {
+		if r.ownDataMessage(messageID) {
+			r.decrease()
+		}
+	}))
+}
+
+func (r *AIMDRateSetter) CanIssue() bool {
+	if r.tangle.Scheduler.IssuerQueueWork(r.tangle.Peer.ID) > config.Params.AIMDQueueThreshold || r.rmcIncreased() {
+		r.decrease()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if now.Before(r.nextIssue) {
+		return false
+	}
+	// the next message can be issued once the work of this one has been paced out at the current rate
+	work := float64(r.tangle.MessageFactory.NextWork())
+	r.nextIssue = now.Add(time.Duration(float64(time.Second) * float64(config.Params.SlowdownFactor) * work / r.rate))
+	return true
+}
+
+// Rate returns the current issuing rate of the node in work units per second.
+func (r *AIMDRateSetter) Rate() float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.rate
+}
+
+func (r *AIMDRateSetter) increase(work int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// the increase is weighted by the work of the message and divided by the rate, so the rate grows by about
+	// AIMDIncrease per second
+	r.rate = math.Min(r.rate+config.Params.AIMDIncrease*float64(work)/r.rate, float64(config.Params.SchedulingRate))
+}
+
+func (r *AIMDRateSetter) decrease() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if time.Since(r.lastDecrease) < config.Params.AIMDHoldTime*time.Duration(config.Params.SlowdownFactor) {
+		return
+	}
+	r.rate = math.Max(r.rate*config.Params.AIMDDecrease, config.Params.AIMDMinRate)
+	r.lastDecrease = time.Now()
+}
+
+// rmcIncreased returns true if the RMC of the current slot is higher than the one seen by the last call.
+func (r *AIMDRateSetter) rmcIncreased() (increased bool) {
+	if !rmcEnabled() {
+		return false
+	}
+	rmc := r.tangle.Storage.RMC(r.tangle.Storage.SlotIndex(time.Now()))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	increased = rmc > r.lastRMC && r.lastRMC != 0
+	r.lastRMC = rmc
+	return
+}
+
+func (r *AIMDRateSetter) ownDataMessage(messageID MessageID) bool {
+	message := r.tangle.Storage.Message(messageID)
+	return message != nil && message.Issuer == r.tangle.Peer.ID && !message.Validation
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TokenBucketRateSetter ////////////////////////////////////////////////////////////////////////////////////////
+
+// TokenBucketRateSetter fills a bucket of TokenBucketSize work units with the bandwidth of the node and only issues a
+// message if the bucket holds enough tokens for its work, so bursts are bounded by the bucket size.
+type TokenBucketRateSetter struct {
+	tangle *Tangle
+
+	tokens     float64
+	lastRefill time.Time
+
+	mutex sync.Mutex
+}
+
+func NewTokenBucketRateSetter(tangle *Tangle) RateSetter {
+	return &TokenBucketRateSetter{
+		tangle: tangle,
+	}
+}
+
+func (r *TokenBucketRateSetter) Setup() {
+	r.tokens = config.Params.TokenBucketSize
+	r.lastRefill = time.Now()
+}
+
+func (r *TokenBucketRateSetter) CanIssue() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	refillRate := float64(r.tangle.BandwidthDistribution.Bandwidth(r.tangle.Peer.ID)) / float64(config.Params.SlowdownFactor)
+	r.tokens = math.Min(r.tokens+now.Sub(r.lastRefill).Seconds()*refillRate, config.Params.TokenBucketSize)
+	r.lastRefill = now
+
+	work := float64(r.tangle.MessageFactory.NextWork())
+	if r.tokens < work {
+		return false
+	}
+	r.tokens -= work
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NoRateSetter /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NoRateSetter issues whenever the node wants to.
+type NoRateSetter struct{}
+
+func NewNoRateSetter(*Tangle) RateSetter {
+	return &NoRateSetter{}
+}
+
+func (r *NoRateSetter) Setup()         {}
+func (r *NoRateSetter) CanIssue() bool { return true }
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,88 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+func TestAIMDRateSetter(t *testing.T) {
+	defer func(increase, decrease, minRate float64, holdTime time.Duration, minWork int) {
+		config.Params.AIMDIncrease, config.Params.AIMDDecrease, config.Params.AIMDMinRate = increase, decrease, minRate
+		config.Params.AIMDHoldTime, config.Params.MinWork = holdTime, minWork
+	}(config.Params.AIMDIncrease, config.Params.AIMDDecrease, config.Params.AIMDMinRate, config.Params.AIMDHoldTime, config.Params.MinWork)
+	config.Params.AIMDIncrease, config.Params.AIMDDecrease, config.Params.AIMDMinRate = 4, 0.5, 1
+	config.Params.AIMDHoldTime, config.Params.MinWork = time.Hour, 2
+
+	tangle := newTestTangle(t, 4)
+	rateSetter := NewAIMDRateSetter(tangle).(*AIMDRateSetter)
+	rateSetter.Setup()
+	if rateSetter.Rate() != 1 {
+		t.Fatalf("initial rate %f is not the bandwidth of the node", rateSetter.Rate())
+	}
+
+	// the rate grows beyond the bandwidth of the node with every scheduled own message, weighted by its work
+	own := newTestMessage(0, false)
+	own.Work = 2
+	tangle.Storage.Store(own)
+	tangle.Scheduler.Events().MessageScheduled.Trigger(own.ID)
+	if rateSetter.Rate() != 1+4*2 {
+		t.Fatalf("rate %f after the increase", rateSetter.Rate())
+	}
+
+	// messages of other nodes do not change the rate
+	other := newTestMessage(1, false)
+	tangle.Storage.Store(other)
+	tangle.Scheduler.Events().MessageScheduled.Trigger(other.ID)
+	if rateSetter.Rate() != 9 {
+		t.Fatalf("rate %f changed by the message of another node", rateSetter.Rate())
+	}
+
+	// the issuance is paced by the work of the next message
+	if !rateSetter.CanIssue() || rateSetter.CanIssue() {
+		t.Fatal("rate setter did not pace the issuance")
+	}
+	expectedPace := time.Duration(float64(time.Second) * float64(config.Params.SlowdownFactor) * 2 / 9)
+	if pace := time.Until(rateSetter.nextIssue); pace > expectedPace || pace < expectedPace-time.Second {
+		t.Fatalf("pace %s instead of %s", pace, expectedPace)
+	}
+
+	// a drop halves the rate once per hold time
+	tangle.Scheduler.Events().MessageDropped.Trigger(own.ID)
+	tangle.Scheduler.Events().MessageDropped.Trigger(own.ID)
+	if rateSetter.Rate() != 4.5 {
+		t.Fatalf("rate %f after two drops within the hold time", rateSetter.Rate())
+	}
+}
+
+func TestTokenBucketRateSetter(t *testing.T) {
+	defer func(bucketSize float64, minWork int) {
+		config.Params.TokenBucketSize, config.Params.MinWork = bucketSize, minWork
+	}(config.Params.TokenBucketSize, config.Params.MinWork)
+	config.Params.TokenBucketSize, config.Params.MinWork = 5, 2
+
+	tangle := newTestTangle(t, 4)
+	rateSetter := NewTokenBucketRateSetter(tangle).(*TokenBucketRateSetter)
+	rateSetter.Setup()
+
+	// a full bucket of 5 work units allows a burst of two messages of 2 work units
+	for i := 0; i < 2; i++ {
+		if !rateSetter.CanIssue() {
+			t.Fatalf("message %d of the burst not issued", i)
+		}
+	}
+	if rateSetter.CanIssue() {
+		t.Fatal("issued more work than the bucket holds")
+	}
+
+	// the bucket is refilled with the bandwidth of the node, but never beyond its size
+	rateSetter.lastRefill = time.Now().Add(-time.Hour)
+	issued := 0
+	for rateSetter.CanIssue() {
+		issued++
+	}
+	if issued != 2 {
+		t.Fatalf("issued %d messages from a refilled bucket", issued)
+	}
+}
@@ -84,6 +84,12 @@ func (r *Requester) retry(messageID MessageID) {
 		return
 	}
 
+	// the message will never be stored anymore
+	if r.tangle.Storage.IsPruned(messageID) {
+		delete(r.queuedElements, messageID)
+		return
+	}
+
 	r.triggerRequestAndScheduleRetry(messageID)
 }
 
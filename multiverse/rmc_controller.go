@@ -0,0 +1,112 @@
+package multiverse
+
+import (
+	"math"
+
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+// region RMCController ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RMCController computes the reference mana cost of the next RMCPeriodUpdate slots from the RMC of the previous slot
+// and the average number of accepted messages per slot in the committed slots of the last update period.
+type RMCController interface {
+	RMC(previousRMC float64, traffic float64) float64
+}
+
+// NewRMCController creates the controller with the given name, falling back to the additive controller.
+func NewRMCController(name string) RMCController {
+	switch name {
+	case "Multiplicative":
+		return &MultiplicativeRMCController{}
+	case "PID":
+		return &PIDRMCController{}
+	case "EIP1559":
+		return &EIP1559RMCController{}
+	default:
+		return &AdditiveRMCController{}
+	}
+}
+
+// rmcTargetTraffic returns the traffic per slot the PID and EIP1559 controllers aim for.
+func rmcTargetTraffic() float64 {
+	if config.Params.RMCTargetTraffic > 0 {
+		return config.Params.RMCTargetTraffic
+	}
+	return (config.Params.LowerRMCThreshold + config.Params.UpperRMCThreshold) / 2
+}
+
+// clampRMC limits the RMC to [RMCmin, RMCmax].
+func clampRMC(rmc float64) float64 {
+	return math.Min(math.Max(rmc, config.Params.RMCmin), config.Params.RMCmax)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AdditiveRMCController ////////////////////////////////////////////////////////////////////////////////////////
+
+// AdditiveRMCController decreases the RMC by RMCdecrease below LowerRMCThreshold and increases it by RMCincrease above
+// UpperRMCThreshold.
+type AdditiveRMCController struct{}
+
+func (c *AdditiveRMCController) RMC(previousRMC float64, traffic float64) float64 {
+	if traffic < config.Params.LowerRMCThreshold {
+		return math.Max(previousRMC-config.Params.RMCdecrease, config.Params.RMCmin)
+	} else if traffic > config.Params.UpperRMCThreshold {
+		return math.Min(previousRMC+config.Params.RMCincrease, config.Params.RMCmax)
+	}
+	return previousRMC
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultiplicativeRMCController //////////////////////////////////////////////////////////////////////////////////
+
+// MultiplicativeRMCController multiplies the RMC by AlphaRMC below LowerRMCThreshold and by BetaRMC above
+// UpperRMCThreshold.
+type MultiplicativeRMCController struct{}
+
+func (c *MultiplicativeRMCController) RMC(previousRMC float64, traffic float64) float64 {
+	if traffic < config.Params.LowerRMCThreshold {
+		return math.Max(previousRMC*config.Params.AlphaRMC, config.Params.RMCmin)
+	} else if traffic > config.Params.UpperRMCThreshold {
+		return math.Min(previousRMC*config.Params.BetaRMC, config.Params.RMCmax)
+	}
+	return previousRMC
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PIDRMCController /////////////////////////////////////////////////////////////////////////////////////////////
+
+// PIDRMCController steers the traffic towards RMCTargetTraffic. The error is the traffic relative to the target and
+// the correction is given in multiples of RMCincrease.
+type PIDRMCController struct {
+	integral      float64
+	previousError float64
+}
+
+func (c *PIDRMCController) RMC(previousRMC float64, traffic float64) float64 {
+	err := (traffic - rmcTargetTraffic()) / rmcTargetTraffic()
+	c.integral += err
+	derivative := err - c.previousError
+	c.previousError = err
+
+	correction := config.Params.RMCKp*err + config.Params.RMCKi*c.integral + config.Params.RMCKd*derivative
+	return clampRMC(previousRMC + correction*config.Params.RMCincrease)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EIP1559RMCController /////////////////////////////////////////////////////////////////////////////////////////
+
+// EIP1559RMCController changes the RMC by the relative deviation of the traffic from RMCTargetTraffic divided by
+// RMCAdjustmentQuotient, like the base fee of EIP-1559.
+type EIP1559RMCController struct{}
+
+func (c *EIP1559RMCController) RMC(previousRMC float64, traffic float64) float64 {
+	deviation := (traffic - rmcTargetTraffic()) / rmcTargetTraffic()
+	return clampRMC(previousRMC * (1 + deviation/config.Params.RMCAdjustmentQuotient))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,73 @@
+package multiverse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+// withRMCParams sets the parameters shared by the RMC controller tests.
+func withRMCParams(t *testing.T) {
+	params := *config.Params.CongestionControlSettings
+	t.Cleanup(func() { *config.Params.CongestionControlSettings = params })
+
+	config.Params.RMCmin, config.Params.RMCmax = 1, 100
+	config.Params.LowerRMCThreshold, config.Params.UpperRMCThreshold = 10, 20
+	config.Params.RMCincrease, config.Params.RMCdecrease = 2, 1
+	config.Params.AlphaRMC, config.Params.BetaRMC = 0.5, 2
+	config.Params.RMCTargetTraffic = 0
+	config.Params.RMCKp, config.Params.RMCKi, config.Params.RMCKd = 1, 0, 0
+	config.Params.RMCAdjustmentQuotient = 8
+}
+
+func TestThresholdRMCControllers(t *testing.T) {
+	withRMCParams(t)
+
+	for name, expected := range map[string][3]float64{
+		"Additive":       {9, 10, 12},
+		"Multiplicative": {5, 10, 20},
+	} {
+		controller := NewRMCController(name)
+		for i, traffic := range []float64{5, 15, 25} {
+			if rmc := controller.RMC(10, traffic); rmc != expected[i] {
+				t.Errorf("%s: RMC %f at traffic %f instead of %f", name, rmc, traffic, expected[i])
+			}
+		}
+		if rmc := controller.RMC(config.Params.RMCmax, 25); rmc != config.Params.RMCmax {
+			t.Errorf("%s: RMC %f above RMCmax", name, rmc)
+		}
+	}
+}
+
+func TestPIDRMCController(t *testing.T) {
+	withRMCParams(t)
+
+	// the target is the middle between the thresholds, 15, and the correction is in multiples of RMCincrease
+	controller := NewRMCController("PID")
+	if rmc := controller.RMC(10, 30); rmc != 12 {
+		t.Errorf("RMC %f at twice the target traffic", rmc)
+	}
+	if rmc := controller.RMC(10, 15); rmc != 10 {
+		t.Errorf("RMC %f at the target traffic", rmc)
+	}
+	if rmc := controller.RMC(1, 0); rmc != config.Params.RMCmin {
+		t.Errorf("RMC %f below RMCmin", rmc)
+	}
+}
+
+func TestEIP1559RMCController(t *testing.T) {
+	withRMCParams(t)
+	config.Params.RMCTargetTraffic = 10
+
+	controller := NewRMCController("EIP1559")
+	if rmc := controller.RMC(16, 20); rmc != 18 {
+		t.Errorf("RMC %f at twice the target traffic", rmc)
+	}
+	if rmc := controller.RMC(16, 0); rmc != 14 {
+		t.Errorf("RMC %f without traffic", rmc)
+	}
+	if rmc := controller.RMC(16, 10); math.IsNaN(rmc) || rmc != 16 {
+		t.Errorf("RMC %f at the target traffic", rmc)
+	}
+}
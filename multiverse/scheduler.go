@@ -1,10 +1,6 @@
 package multiverse
 
 import (
-	"container/heap"
-	"container/ring"
-	"time"
-
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/multivers-simulation/config"
 	"github.com/iotaledger/multivers-simulation/network"
@@ -17,72 +13,51 @@ type PriorityQueue []Message
 
 type IssuerQueue []Message
 
-type BurnPolicyType int
-
-const (
-	NoBurn   BurnPolicyType = 0
-	Anxious  BurnPolicyType = 1
-	Greedy1  BurnPolicyType = 2
-	Greedy10 BurnPolicyType = 3
-)
-
+// Scheduler decides in which order the enqueued messages are scheduled and gossiped. The mana accounting and the rate
+// setting are done by the ManaManager and the RateSetter of the tangle, so all three can be combined freely.
 type Scheduler interface {
 	Setup()
-	IncrementAccessMana(float64)
-	DecreaseNodeAccessMana(network.PeerID, float64) float64
-	BurnValue(time.Time) (float64, bool)
 	EnqueueMessage(MessageID)
 	ScheduleMessage()
 	Events() *SchedulerEvents
 	ReadyLen() int
 	NonReadyLen() int
-	GetNodeAccessMana(network.PeerID) float64
 	GetMaxManaBurn() float64
 	IssuerQueueLen(network.PeerID) int
+	IssuerQueueWork(network.PeerID) int
 	Deficit(network.PeerID) float64
-	RateSetter() bool
 }
 
-func NewScheduler(tangle *Tangle) (s Scheduler) {
-	if config.Params.SchedulerType == "ManaBurn" {
-		readyHeap := &PriorityQueue{}
-		heap.Init(readyHeap)
-		s = &MBScheduler{
-			tangle:      tangle,
-			readyQueue:  readyHeap,
-			nonReadyMap: make(map[MessageID]*Message),
-			accessMana:  make(map[network.PeerID]float64, config.Params.NodesCount),
-			events: &SchedulerEvents{
-				MessageScheduled: events.NewEvent(messageIDEventCaller),
-				MessageDropped:   events.NewEvent(messageIDEventCaller),
-				MessageEnqueued:  events.NewEvent(schedulerEventCaller),
-			},
-		}
-	} else if config.Params.SchedulerType == "ICCA+" {
-		s = &ICCAScheduler{
-			tangle:       tangle,
-			nonReadyMap:  make(map[MessageID]*Message),
-			accessMana:   make(map[network.PeerID]float64, config.Params.NodesCount),
-			deficits:     make(map[network.PeerID]float64, config.Params.NodesCount),
-			quanta:       make(map[network.PeerID]float64, config.Params.NodesCount),
-			issuerQueues: make(map[network.PeerID]*IssuerQueue, config.Params.NodesCount),
-			roundRobin:   ring.New(config.Params.NodesCount),
-			events: &SchedulerEvents{
-				MessageScheduled: events.NewEvent(messageIDEventCaller),
-				MessageDropped:   events.NewEvent(messageIDEventCaller),
-				MessageEnqueued:  events.NewEvent(schedulerEventCaller),
-			},
-		}
-	} else {
-		s = &NoScheduler{
-			tangle: tangle,
+// SchedulerFactory creates a Scheduler for the given tangle.
+type SchedulerFactory func(tangle *Tangle) Scheduler
+
+// schedulerFactories contains the schedulers that can be selected with SchedulerType.
+var schedulerFactories = map[string]SchedulerFactory{
+	"ManaBurn": NewMBScheduler,
+	"ICCA+":    NewICCAScheduler,
+	"None":     NewNoScheduler,
+}
 
-			events: &SchedulerEvents{
-				MessageScheduled: events.NewEvent(messageIDEventCaller),
-			},
-		}
+// RegisterScheduler makes a scheduler available under the given SchedulerType, so new congestion control algorithms
+// can be added without modifying this package.
+func RegisterScheduler(name string, factory SchedulerFactory) {
+	schedulerFactories[name] = factory
+}
+
+// NewScheduler creates the scheduler configured in SchedulerType, unknown types do not schedule at all.
+func NewScheduler(tangle *Tangle) Scheduler {
+	if factory, exists := schedulerFactories[config.Params.SchedulerType]; exists {
+		return factory(tangle)
+	}
+	return NewNoScheduler(tangle)
+}
+
+func newSchedulerEvents() *SchedulerEvents {
+	return &SchedulerEvents{
+		MessageScheduled: events.NewEvent(messageIDEventCaller),
+		MessageDropped:   events.NewEvent(messageIDEventCaller),
+		MessageEnqueued:  events.NewEvent(schedulerEventCaller),
 	}
-	return
 }
 
 // / region Priority Queue ////////////////////////////////////////////////////////////////////////////////
@@ -114,15 +89,6 @@ func (h *PriorityQueue) Pop() any {
 	return x
 }
 
-func (h PriorityQueue) tail() (tail int) {
-	for i := range h {
-		if !h.Less(i, tail) { // less means more mana burned/older issue time
-			tail = i
-		}
-	}
-	return
-}
-
 // region Issuer Queue ////////////////////////////////////////////////////////////////////////////////
 func (h IssuerQueue) Len() int { return len(h) }
 func (h IssuerQueue) Less(i, j int) bool {
@@ -0,0 +1,138 @@
+package multiverse
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region SchedulerAnalytics ///////////////////////////////////////////////////////////////////////////////////////////
+
+// SchedulerAnalytics collects the scheduling delays and the non-ready queueing times of the data messages per issuer
+// from the message metadata and estimates the scheduled throughput of every issuer for the fairness index.
+type SchedulerAnalytics struct {
+	tangle *Tangle
+
+	issuerStats map[network.PeerID]*IssuerSchedulingStats
+	// throughputs are the exponentially decaying scheduled work per second of every issuer at lastScheduled
+	throughputs   map[network.PeerID]float64
+	lastScheduled map[network.PeerID]time.Time
+
+	mutex sync.RWMutex
+}
+
+// IssuerSchedulingStats are the scheduling results of the data messages of an issuer at a node.
+type IssuerSchedulingStats struct {
+	ScheduledMessages int
+	DroppedMessages   int
+	// SchedulingDelay is the sum of the times between enqueuing and scheduling of the scheduled messages
+	SchedulingDelay    time.Duration
+	MaxSchedulingDelay time.Duration
+	// NonReadyMessages is the number of scheduled messages that waited in the non-ready map
+	NonReadyMessages int
+	// NonReadyTime is the sum of the times between enqueuing and becoming ready of the scheduled messages
+	NonReadyTime time.Duration
+}
+
+func NewSchedulerAnalytics(tangle *Tangle) *SchedulerAnalytics {
+	return &SchedulerAnalytics{
+		tangle:        tangle,
+		issuerStats:   make(map[network.PeerID]*IssuerSchedulingStats),
+		throughputs:   make(map[network.PeerID]float64),
+		lastScheduled: make(map[network.PeerID]time.Time),
+	}
+}
+
+func (a *SchedulerAnalytics) Setup() {
+	a.tangle.Scheduler.Events().MessageScheduled.Attach(events.NewClosure(a.messageScheduled))
+	a.tangle.Scheduler.Events().MessageDropped.Attach(events.NewClosure(func(messageID MessageID) {
+		if message := a.tangle.Storage.Message(messageID); message != nil && !message.Validation {
+			a.mutex.Lock()
+			defer a.mutex.Unlock()
+			a.stats(message.Issuer).DroppedMessages++
+		}
+	}))
+}
+
+func (a *SchedulerAnalytics) messageScheduled(messageID MessageID) {
+	message := a.tangle.Storage.Message(messageID)
+	messageMetadata := a.tangle.Storage.MessageMetadata(messageID)
+	if message == nil || messageMetadata == nil || message.Validation {
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	stats := a.stats(message.Issuer)
+	stats.ScheduledMessages++
+	schedulingDelay := messageMetadata.ScheduleTime().Sub(messageMetadata.EnqueueTime())
+	stats.SchedulingDelay += schedulingDelay
+	if schedulingDelay > stats.MaxSchedulingDelay {
+		stats.MaxSchedulingDelay = schedulingDelay
+	}
+	if nonReadyTime := messageMetadata.ReadyTime().Sub(messageMetadata.EnqueueTime()); nonReadyTime > 0 {
+		stats.NonReadyMessages++
+		stats.NonReadyTime += nonReadyTime
+	}
+
+	now := time.Now()
+	a.throughputs[message.Issuer] = a.throughput(message.Issuer, now) + float64(message.ScheduledWork())/issuingRateWindow().Seconds()
+	a.lastScheduled[message.Issuer] = now
+}
+
+// IssuerStats returns a copy of the scheduling results of all issuers with scheduled or dropped messages.
+func (a *SchedulerAnalytics) IssuerStats() (issuerStats map[network.PeerID]IssuerSchedulingStats) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	issuerStats = make(map[network.PeerID]IssuerSchedulingStats, len(a.issuerStats))
+	for issuer, stats := range a.issuerStats {
+		issuerStats[issuer] = *stats
+	}
+	return
+}
+
+// FairnessIndex returns Jain's fairness index of the scheduled throughput of the active issuers relative to their
+// bandwidth. An issuer is active if it has queued messages or recently scheduled messages.
+func (a *SchedulerAnalytics) FairnessIndex() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	now := time.Now()
+	sum, squaredSum, activeIssuers := 0.0, 0.0, 0
+	for id := 0; id < config.Params.NodesCount; id++ {
+		issuer := network.PeerID(id)
+		bandwidth := a.tangle.BandwidthDistribution.Bandwidth(issuer)
+		throughput := a.throughput(issuer, now)
+		if bandwidth <= 0 || (throughput < 1e-3 && a.tangle.Scheduler.IssuerQueueLen(issuer) == 0) {
+			continue
+		}
+		share := throughput / bandwidth
+		sum += share
+		squaredSum += share * share
+		activeIssuers++
+	}
+	if squaredSum == 0 {
+		return 1
+	}
+	return sum * sum / (float64(activeIssuers) * squaredSum)
+}
+
+// throughput returns the scheduled work per second of the issuer decayed to the given time.
+func (a *SchedulerAnalytics) throughput(issuer network.PeerID, now time.Time) float64 {
+	return a.throughputs[issuer] * math.Exp(-now.Sub(a.lastScheduled[issuer]).Seconds()/issuingRateWindow().Seconds())
+}
+
+func (a *SchedulerAnalytics) stats(issuer network.PeerID) *IssuerSchedulingStats {
+	stats, exists := a.issuerStats[issuer]
+	if !exists {
+		stats = &IssuerSchedulingStats{}
+		a.issuerStats[issuer] = stats
+	}
+	return stats
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -72,6 +72,11 @@ func (s *Solidifier) parentsSolid(parentMessageIDs MessageIDs) (parentsSolid boo
 
 		parentMessageMetadata := s.tangle.Storage.MessageMetadata(parentMessageID)
 		if parentMessageMetadata == nil {
+			// the past cone of pruned messages was solid already
+			if s.tangle.Storage.IsPruned(parentMessageID) {
+				continue
+			}
+
 			s.Events.MessageMissing.Trigger(parentMessageID)
 			log.Debug("Solidification request sent.")
 			parentsSolid = false
@@ -0,0 +1,135 @@
+package multiverse
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region StakeManaManager /////////////////////////////////////////////////////////////////////////////////////////////
+
+// StakeManaManager generates ManaGenerationRate mana per slot for every unit of stake of an account, lets the mana of
+// all accounts decay by ManaDecay per slot and caps it at ManaCap. Messages burn the RMC of their slot, or bid
+// according to the burn policy if the ManaBurn scheduler is used.
+type StakeManaManager struct {
+	*manaAccounts
+	stake  map[network.PeerID]float64
+	bidder *BurnBidder
+}
+
+func NewStakeManaManager(tangle *Tangle) ManaManager {
+	return &StakeManaManager{
+		manaAccounts: newManaAccounts(tangle),
+		stake:        make(map[network.PeerID]float64, config.Params.NodesCount),
+		bidder:       NewBurnBidder(tangle),
+	}
+}
+
+func (m *StakeManaManager) Setup() {
+	allocation := loadManaAllocation()
+	for id := 0; id < config.Params.NodesCount; id++ {
+		peerID := network.PeerID(id)
+		if allocation == nil {
+			m.stake[peerID] = float64(m.tangle.WeightDistribution.Weight(peerID))
+		} else {
+			m.stake[peerID] = allocation.stake[peerID]
+		}
+	}
+	m.setup(func(peerID network.PeerID) float64 {
+		if allocation != nil {
+			if mana, exists := allocation.mana[peerID]; exists {
+				return mana
+			}
+		}
+		return config.Params.InitialMana
+	})
+	m.bidder.Setup()
+}
+
+// IncrementAccessMana generates and decays the mana of all slots that started since the last call.
+func (m *StakeManaManager) IncrementAccessMana(float64) {
+	m.increment(nil, func(id network.PeerID, mana float64) float64 {
+		return capMana(mana*(1-config.Params.ManaDecay) + m.stake[id]*config.Params.ManaGenerationRate)
+	})
+}
+
+func (m *StakeManaManager) BurnValue(issuanceTime time.Time) (float64, bool) {
+	if config.Params.SchedulerType == "ManaBurn" {
+		return m.bidder.BurnValue(m)
+	}
+	return rmcBurnValue(m.tangle, m, issuanceTime)
+}
+
+// Stake returns the stake of the given node.
+func (m *StakeManaManager) Stake(nodeID network.PeerID) float64 {
+	return m.stake[nodeID]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ManaAllocation ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// manaAllocation is the stake and the initial mana of the accounts read from the ManaAllocationFile.
+type manaAllocation struct {
+	stake map[network.PeerID]float64
+	mana  map[network.PeerID]float64
+}
+
+var (
+	sharedManaAllocation     *manaAllocation
+	sharedManaAllocationOnce sync.Once
+)
+
+// loadManaAllocation reads the ManaAllocationFile once for all nodes, it returns nil if no file is configured.
+func loadManaAllocation() *manaAllocation {
+	sharedManaAllocationOnce.Do(func() {
+		if config.Params.ManaAllocationFile == "" {
+			return
+		}
+		file, err := os.Open(config.Params.ManaAllocationFile)
+		if err != nil {
+			panic(err)
+		}
+		defer file.Close()
+
+		sharedManaAllocation = &manaAllocation{
+			stake: make(map[network.PeerID]float64),
+			mana:  make(map[network.PeerID]float64),
+		}
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				panic(err)
+			}
+			id, err := strconv.Atoi(record[0])
+			if err != nil {
+				// skip the header
+				continue
+			}
+			if len(record) > 1 {
+				if sharedManaAllocation.stake[network.PeerID(id)], err = strconv.ParseFloat(record[1], 64); err != nil {
+					panic(err)
+				}
+			}
+			if len(record) > 2 {
+				if sharedManaAllocation.mana[network.PeerID(id)], err = strconv.ParseFloat(record[2], 64); err != nil {
+					panic(err)
+				}
+			}
+		}
+	})
+	return sharedManaAllocation
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
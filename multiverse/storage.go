@@ -1,7 +1,6 @@
 package multiverse
 
 import (
-	"math"
 	"sync"
 	"time"
 
@@ -11,9 +10,15 @@ import (
 
 // region Storage //////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// prunedSlotsWindow is the number of pruned slots for which the IDs of the pruned messages are kept, so that late
+// references to them are not requested from the neighbors again.
+const prunedSlotsWindow = 10
+
 type Storage struct {
 	Events *StorageEvents
 
+	// messageStore is the shared message and children index, nil if every node keeps its own copy
+	messageStore      *MessageStore
 	messageDB         map[MessageID]*Message
 	messageMetadataDB map[MessageID]*MessageMetadata
 	strongChildrenDB  map[MessageID]MessageIDs
@@ -21,16 +26,27 @@ type Storage struct {
 	slotDB            map[SlotIndex]MessageIDs
 	acceptedSlotDB    map[SlotIndex]MessageIDs
 	rmc               map[SlotIndex]float64
+	rmcController     RMCController
 	genesisTime       time.Time
 	ATT               time.Time
 
+	// all slots before prunedSlot have been pruned, prunedMessages holds the IDs of the messages that were pruned or
+	// rejected because their slot had already been pruned, for the last prunedSlotsWindow pruned slots only, as
+	// message IDs do not follow the slot order
+	prunedSlot              SlotIndex
+	prunedMessages          map[SlotIndex]MessageIDs
+	prunedSlotSizes         map[SlotIndex]int
+	prunedAcceptedSlotSizes map[SlotIndex]int
+
 	slotMutex sync.Mutex
 }
 
 func NewStorage() (storage *Storage) {
-	return &Storage{
+	storage = &Storage{
 		Events: &StorageEvents{
-			MessageStored: events.NewEvent(messageEventCaller),
+			MessageStored:  events.NewEvent(messageEventCaller),
+			MessageExpired: events.NewEvent(messageEventCaller),
+			MessagesPruned: events.NewEvent(messageIDsEventCaller),
 		},
 
 		messageDB:         make(map[MessageID]*Message),
@@ -40,7 +56,16 @@ func NewStorage() (storage *Storage) {
 		slotDB:            make(map[SlotIndex]MessageIDs),
 		acceptedSlotDB:    make(map[SlotIndex]MessageIDs),
 		rmc:               make(map[SlotIndex]float64),
+		rmcController:     NewRMCController(config.Params.RMCController),
+
+		prunedMessages:          make(map[SlotIndex]MessageIDs),
+		prunedSlotSizes:         make(map[SlotIndex]int),
+		prunedAcceptedSlotSizes: make(map[SlotIndex]int),
 	}
+	if config.Params.SharedMessageStore {
+		storage.messageStore = sharedStore
+	}
+	return
 }
 
 func (s *Storage) Setup(genesisTime time.Time) {
@@ -49,12 +74,18 @@ func (s *Storage) Setup(genesisTime time.Time) {
 }
 
 func (s *Storage) Store(message *Message) (*MessageMetadata, bool) {
-	if _, exists := s.messageDB[message.ID]; exists {
+	if _, exists := s.messageMetadataDB[message.ID]; exists {
 		return &MessageMetadata{}, false
 	}
 	slotIndex := s.SlotIndex(message.IssuanceTime)
 	s.slotMutex.Lock()
 	defer s.slotMutex.Unlock()
+	if slotIndex < s.prunedSlot {
+		if prunedMessages, exists := s.prunedMessages[slotIndex]; exists {
+			prunedMessages.Add(message.ID)
+		}
+		return &MessageMetadata{}, false // don't store messages of slots that have already been pruned
+	}
 	if _, exists := s.slotDB[slotIndex]; !exists {
 		s.slotDB[slotIndex] = NewMessageIDs()
 	}
@@ -68,25 +99,41 @@ func (s *Storage) Store(message *Message) (*MessageMetadata, bool) {
 	// store to slot storage
 	s.slotDB[slotIndex].Add(message.ID)
 	// store message and metadata
-	s.messageDB[message.ID] = message
 	messageMetadata := &MessageMetadata{
 		id:          message.ID,
-		weightSlice: make([]byte, int(math.Ceil(float64(config.Params.NodesCount)/8.0))),
-		arrivalTime: time.Now(),
+		arrivalTime: newCompactTime(time.Now()),
 		ready:       false,
 	}
 	// check if this should be orphaned
-	if s.TooOld(message) {
+	if s.Orphanable(message) {
 		messageMetadata.SetOrphanTime(time.Now())
 	}
 	s.messageMetadataDB[message.ID] = messageMetadata
+	if s.messageStore != nil {
+		s.messageStore.Reference(message)
+		return messageMetadata, true
+	}
+	s.messageDB[message.ID] = message
 	// store child references
-	s.storeChildReferences(message.ID, s.strongChildrenDB, message.StrongParents)
-	s.storeChildReferences(message.ID, s.weakChildrenDB, message.WeakParents)
+	storeChildReferences(message.ID, s.strongChildrenDB, message.StrongParents)
+	storeChildReferences(message.ID, s.weakChildrenDB, message.WeakParents)
 	return messageMetadata, true
 }
 
+// MessagesCount returns the number of messages currently held in the storage.
+func (s *Storage) MessagesCount() int {
+	s.slotMutex.Lock()
+	defer s.slotMutex.Unlock()
+	return len(s.messageMetadataDB)
+}
+
 func (s *Storage) Message(messageID MessageID) (message *Message) {
+	if s.messageStore != nil {
+		if _, exists := s.messageMetadataDB[messageID]; !exists {
+			return nil
+		}
+		return s.messageStore.Message(messageID)
+	}
 	return s.messageDB[messageID]
 }
 
@@ -95,20 +142,92 @@ func (s *Storage) MessageMetadata(messageID MessageID) (messageMetadata *Message
 }
 
 func (s *Storage) StrongChildren(messageID MessageID) (strongChildren MessageIDs) {
+	if s.messageStore != nil {
+		return s.messageStore.StrongChildren(messageID, s.stored)
+	}
 	return s.strongChildrenDB[messageID]
 }
 
 func (s *Storage) WeakChildren(messageID MessageID) (weakChildren MessageIDs) {
+	if s.messageStore != nil {
+		return s.messageStore.WeakChildren(messageID, s.stored)
+	}
 	return s.weakChildrenDB[messageID]
 }
 
-func (s *Storage) storeChildReferences(messageID MessageID, childReferenceDB map[MessageID]MessageIDs, parents MessageIDs) {
-	for parent := range parents {
-		if _, exists := childReferenceDB[parent]; !exists {
-			childReferenceDB[parent] = NewMessageIDs()
+// stored returns true if the message has been stored by this node, the shared message store also knows the messages
+// that only arrived at other nodes.
+func (s *Storage) stored(messageID MessageID) bool {
+	_, exists := s.messageMetadataDB[messageID]
+	return exists
+}
+
+// IsPruned returns true if the message is not in the storage because its slot has already been pruned. Messages of
+// slots that were pruned more than prunedSlotsWindow slots ago are not known anymore and count as missing.
+func (s *Storage) IsPruned(messageID MessageID) bool {
+	s.slotMutex.Lock()
+	defer s.slotMutex.Unlock()
+	for _, prunedMessages := range s.prunedMessages {
+		if _, pruned := prunedMessages[messageID]; pruned {
+			return true
+		}
+	}
+	return false
+}
+
+// Prune removes the messages of all slots that are more than PruningHorizon slots older than the accepted tangle time.
+// Only the number of (accepted) messages per slot is kept for the traffic and RMC calculations. Messages that are still
+// undecided can not be confirmed anymore once their data is gone, so MessageExpired is triggered for them beforehand.
+func (s *Storage) Prune() {
+	if config.Params.PruningHorizon <= 0 {
+		return
+	}
+
+	s.slotMutex.Lock()
+	targetSlot := s.SlotIndex(s.ATT) - SlotIndex(config.Params.PruningHorizon)
+	expiredMessages := make([]*Message, 0)
+	for slotIndex := s.prunedSlot; slotIndex < targetSlot; slotIndex++ {
+		for messageID := range s.slotDB[slotIndex] {
+			if messageMetadata := s.messageMetadataDB[messageID]; messageMetadata != nil && !messageMetadata.Confirmed() && !messageMetadata.Orphaned() {
+				expiredMessages = append(expiredMessages, s.Message(messageID))
+			}
+		}
+	}
+	s.slotMutex.Unlock()
+
+	// the handlers might access the storage, so the lock is released while they run
+	for _, message := range expiredMessages {
+		s.Events.MessageExpired.Trigger(message.ID, message, s.MessageMetadata(message.ID))
+	}
+
+	s.slotMutex.Lock()
+	prunedMessages := NewMessageIDs()
+	for ; s.prunedSlot < targetSlot; s.prunedSlot++ {
+		for messageID := range s.slotDB[s.prunedSlot] {
+			if s.messageStore != nil && s.stored(messageID) {
+				s.messageStore.Release(messageID)
+			}
+			delete(s.messageDB, messageID)
+			delete(s.messageMetadataDB, messageID)
+			delete(s.strongChildrenDB, messageID)
+			delete(s.weakChildrenDB, messageID)
+			prunedMessages.Add(messageID)
+		}
+		s.prunedSlotSizes[s.prunedSlot] = len(s.slotDB[s.prunedSlot])
+		s.prunedAcceptedSlotSizes[s.prunedSlot] = len(s.acceptedSlotDB[s.prunedSlot])
+		// the IDs of the slot are reused for the window, so the messages are not requested again right away
+		if s.slotDB[s.prunedSlot] == nil {
+			s.slotDB[s.prunedSlot] = NewMessageIDs()
 		}
+		s.prunedMessages[s.prunedSlot] = s.slotDB[s.prunedSlot]
+		delete(s.prunedMessages, s.prunedSlot-prunedSlotsWindow)
+		delete(s.slotDB, s.prunedSlot)
+		delete(s.acceptedSlotDB, s.prunedSlot)
+	}
+	s.slotMutex.Unlock()
 
-		childReferenceDB[parent].Add(messageID)
+	if len(prunedMessages) > 0 {
+		s.Events.MessagesPruned.Trigger(prunedMessages)
 	}
 }
 
@@ -123,6 +242,9 @@ func (s *Storage) isReady(messageID MessageID) bool {
 		}
 		strongParentMetadata := s.MessageMetadata(strongParentID)
 		if strongParentMetadata == nil {
+			if s.IsPruned(strongParentID) {
+				continue
+			}
 			panic("Strong Parent Metadata is empty")
 		}
 		if !strongParentMetadata.Eligible() {
@@ -131,7 +253,7 @@ func (s *Storage) isReady(messageID MessageID) bool {
 	}
 	for weakParentID := range message.WeakParents {
 		weakParentMetadata := s.MessageMetadata(weakParentID)
-		if weakParentID == Genesis {
+		if weakParentID == Genesis || s.IsPruned(weakParentID) {
 			continue
 		}
 		if !weakParentMetadata.Eligible() {
@@ -154,11 +276,40 @@ func (s *Storage) AcceptedSlot(index SlotIndex) MessageIDs {
 	return s.acceptedSlotDB[index]
 }
 
+// MessagesInSlots returns the messages issued in the slots from startSlot to endSlot (both included), negative values
+// are unbounded.
+func (s *Storage) MessagesInSlots(startSlot SlotIndex, endSlot SlotIndex) (messageIDs MessageIDs) {
+	s.slotMutex.Lock()
+	defer s.slotMutex.Unlock()
+
+	messageIDs = NewMessageIDs()
+	for slotIndex, slotMessageIDs := range s.slotDB {
+		if (startSlot >= 0 && slotIndex < startSlot) || (endSlot >= 0 && slotIndex > endSlot) {
+			continue
+		}
+		for messageID := range slotMessageIDs {
+			messageIDs.Add(messageID)
+		}
+	}
+	return
+}
+
+// AcceptedSlotSize returns the number of accepted messages of the slot, also for slots that have been pruned.
+func (s *Storage) AcceptedSlotSize(index SlotIndex) int {
+	if index < s.prunedSlot {
+		return s.prunedAcceptedSlotSizes[index]
+	}
+	return len(s.acceptedSlotDB[index])
+}
+
 // Get the messages count per slot
 func (s *Storage) MessagesCountPerSlot() map[SlotIndex]int {
 	s.slotMutex.Lock()
 	defer s.slotMutex.Unlock()
 	counts := make(map[SlotIndex]int)
+	for slotIndex, count := range s.prunedSlotSizes {
+		counts[slotIndex] = count
+	}
 	for slotIndex, messages := range s.slotDB {
 		counts[slotIndex] = len(messages)
 	}
@@ -169,6 +320,10 @@ func (s *Storage) MessagesCountPerSlot() map[SlotIndex]int {
 func (s *Storage) MessagesCountInRange(startSlotIndex SlotIndex, endSlotIndex SlotIndex) int {
 	count := 0
 	for slotIndex := startSlotIndex; slotIndex < endSlotIndex; slotIndex++ {
+		if slotIndex < s.prunedSlot {
+			count += s.prunedSlotSizes[slotIndex]
+			continue
+		}
 		if _, exists := s.slotDB[slotIndex]; !exists {
 			continue
 		}
@@ -210,7 +365,7 @@ func (s *Storage) RMC(slotIndex SlotIndex) float64 {
 
 func (s *Storage) NewRMC(currentSlotIndex SlotIndex) {
 	currentSlotStartTime := s.genesisTime.Add(time.Duration(float64(currentSlotIndex)*float64(config.Params.SlowdownFactor)) * config.Params.SlotTime)
-	if config.Params.SchedulerType != "ICCA+" {
+	if !rmcEnabled() {
 		s.rmc[currentSlotIndex] = 0.0
 		return
 	}
@@ -222,61 +377,41 @@ func (s *Storage) NewRMC(currentSlotIndex SlotIndex) {
 
 	// Update the RMC every RMCPeriodUpdate
 	if currentSlotStartTime.After(s.genesisTime.Add(config.Params.RMCTime * time.Duration(config.Params.SlowdownFactor))) {
-		// log.Debugf("CurrentSlotIndex %d", currentSlotIndex)
 		if int(currentSlotIndex)%config.Params.RMCPeriodUpdate == 0 {
-			traffic := s.MessagesCountInRange(
-				currentSlotIndex-SlotIndex(config.Params.MinCommittableAge/config.Params.SlotTime)-SlotIndex(config.Params.RMCPeriodUpdate),
-				currentSlotIndex-SlotIndex(config.Params.MinCommittableAge/config.Params.SlotTime)) / config.Params.RMCPeriodUpdate
-
-			// currentSlotIndex-SlotIndex(config.Params.RMCTime/config.Params.SlotTime)-SlotIndex(config.Params.RMCPeriodUpdate),
-			// currentSlotIndex-SlotIndex(config.Params.RMCTime/config.Params.SlotTime))
-
-			// a := currentSlotIndex-SlotIndex(config.Params.RMCTime/config.Params.SlotTime)-SlotIndex(config.Params.RMCPeriodUpdate)
-			// b := currentSlotIndex-SlotIndex(config.Params.RMCTime/config.Params.SlotTime)
-
-			// traffic := 0
-			// for i := 0; i < config.Params.RMCPeriodUpdate; i++ {
-			// 	// traffic += len(s.AcceptedSlot(s.SlotIndex(currentSlotStartTime.Add(-config.Params.MinCommittableAge-time.Duration(i) * config.Params.SlotTime))))
-			// 	traffic += len(s.AcceptedSlot(s.SlotIndex(currentSlotStartTime.Add(-config.Params.RMCTime -time.Duration(i) * config.Params.SlotTime)))) // number of messages k slots in the past
-			// }
-			// MessagesCountInRange
-			// log.Debugf("Traffic: %d, Slot: %d, Slot a: %d, Slot b: %d", traffic, currentSlotIndex, a, b)
-			// traffic = traffic
-			// log.Debugf("Enter Branch, traffic after division: %d", traffic)
-
-			// Modified
-			// if traffic < config.Params.RMCPeriodUpdate*int(config.Params.LowerRMCThreshold) {
-			// 	s.rmc[currentSlotIndex] = math.Max(config.Params.RMCmin, s.rmc[currentSlotIndex]*config.Params.AlphaRMC)
-			// } else if traffic > config.Params.RMCPeriodUpdate*int(config.Params.UpperRMCThreshold) {
-			// 	s.rmc[currentSlotIndex] = math.Min(config.Params.RMCmax, s.rmc[currentSlotIndex]*config.Params.BetaRMC)
-			// }
-
-			// log.Debugf("Traffic: %d", traffic)
-			if traffic < int(config.Params.LowerRMCThreshold) {
-				for i := 0; i < config.Params.RMCPeriodUpdate; i++ {
-					s.rmc[currentSlotIndex+SlotIndex(i)] = math.Max(
-						s.rmc[currentSlotIndex-SlotIndex(1)]-config.Params.RMCdecrease, config.Params.RMCmin)
-				}
-				// log.Debugf("LOW!!!!, rmc = %f", s.rmc[currentSlotIndex])
-			} else if traffic > int(config.Params.UpperRMCThreshold) {
-				for i := 0; i < config.Params.RMCPeriodUpdate; i++ {
-					s.rmc[currentSlotIndex+SlotIndex(i)] = math.Min(
-						s.rmc[currentSlotIndex-SlotIndex(1)]+config.Params.RMCincrease, config.Params.RMCmax)
-				}
-				// log.Debugf("HIGH!!!!, rmc = %f", s.rmc[currentSlotIndex])
-			} else {
-				for i := 0; i < config.Params.RMCPeriodUpdate; i++ {
-					s.rmc[currentSlotIndex+SlotIndex(i)] = s.rmc[currentSlotIndex-SlotIndex(1)]
-				}
+			committedSlotIndex := currentSlotIndex - SlotIndex(config.Params.MinCommittableAge/config.Params.SlotTime)
+			traffic := float64(s.CommittedTraffic(committedSlotIndex-SlotIndex(config.Params.RMCPeriodUpdate), committedSlotIndex)) / float64(config.Params.RMCPeriodUpdate)
+
+			rmc := s.rmcController.RMC(s.rmc[currentSlotIndex-SlotIndex(1)], traffic)
+			for i := 0; i < config.Params.RMCPeriodUpdate; i++ {
+				s.rmc[currentSlotIndex+SlotIndex(i)] = rmc
 			}
 		}
 	}
 }
 
+// CommittedTraffic returns the number of accepted messages in the committed slots from startSlotIndex to
+// endSlotIndex (excluded).
+func (s *Storage) CommittedTraffic(startSlotIndex SlotIndex, endSlotIndex SlotIndex) (traffic int) {
+	for slotIndex := startSlotIndex; slotIndex < endSlotIndex; slotIndex++ {
+		traffic += s.AcceptedSlotSize(slotIndex)
+	}
+	return
+}
+
 func (s *Storage) TooOld(message *Message) bool {
 	return message.IssuanceTime.Before(s.ATT.Add(-config.Params.MinCommittableAge * time.Duration(config.Params.SlowdownFactor)))
 }
 
+// Orphanable returns true if the message should be orphaned instead of confirmed according to the OrphanageRule.
+func (s *Storage) Orphanable(message *Message) bool {
+	switch config.Params.OrphanageRule {
+	case "None":
+		return false
+	default:
+		return s.TooOld(message)
+	}
+}
+
 func (s *Storage) AddToAcceptedSlot(message *Message) {
 	s.slotMutex.Lock()
 	defer s.slotMutex.Unlock()
@@ -298,12 +433,19 @@ func (s *Storage) AddToAcceptedSlot(message *Message) {
 
 type StorageEvents struct {
 	MessageStored *events.Event
+	// MessageExpired is triggered for every undecided message that is about to be pruned.
+	MessageExpired *events.Event
+	MessagesPruned *events.Event
 }
 
 func messageEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(MessageID, *Message, *MessageMetadata))(params[0].(MessageID), params[1].(*Message), params[2].(*MessageMetadata))
 }
 
+func messageIDsEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(MessageIDs))(params[0].(MessageIDs))
+}
+
 func messageIDEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(MessageID))(params[0].(MessageID))
 }
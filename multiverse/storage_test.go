@@ -0,0 +1,129 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+func TestStoragePrune(t *testing.T) {
+	defer func(horizon int) { config.Params.PruningHorizon = horizon }(config.Params.PruningHorizon)
+	config.Params.PruningHorizon = 1
+
+	genesisTime := time.Now()
+	slotDuration := config.Params.SlotTime * time.Duration(config.Params.SlowdownFactor)
+	storage := NewStorage()
+	storage.Setup(genesisTime)
+
+	expired := NewMessageIDs()
+	storage.Events.MessageExpired.Attach(events.NewClosure(func(messageID MessageID, _ *Message, messageMetadata *MessageMetadata) {
+		expired.Add(messageID)
+		messageMetadata.SetOrphanTime(time.Now())
+	}))
+
+	missing := NewMessageID()
+	confirmed := newTestMessage(1, false)
+	undecided := newTestMessage(1, false)
+	recent := newTestMessage(1, false)
+	recent.IssuanceTime = genesisTime.Add(3 * slotDuration)
+	for _, message := range []*Message{confirmed, undecided, recent} {
+		if _, stored := storage.Store(message); !stored {
+			t.Fatalf("message %d not stored", message.ID)
+		}
+	}
+	storage.MessageMetadata(confirmed.ID).SetConfirmationTime(time.Now())
+
+	storage.ATT = genesisTime.Add(3 * slotDuration)
+	storage.Prune()
+
+	if _, undecidedExpired := expired[undecided.ID]; len(expired) != 1 || !undecidedExpired {
+		t.Fatalf("expected only the undecided message to expire, got %v", expired)
+	}
+	for _, messageID := range []MessageID{confirmed.ID, undecided.ID} {
+		if !storage.IsPruned(messageID) || storage.MessageMetadata(messageID) != nil {
+			t.Errorf("message %d of a pruned slot not pruned", messageID)
+		}
+	}
+	if storage.IsPruned(recent.ID) || storage.MessageMetadata(recent.ID) == nil {
+		t.Error("message of a recent slot pruned")
+	}
+	// IDs are global, so a lower ID that never arrived must still be reported as missing
+	if storage.IsPruned(missing) {
+		t.Error("missing message reported as pruned")
+	}
+
+	late := newTestMessage(1, false)
+	if _, stored := storage.Store(late); stored {
+		t.Fatal("message of a pruned slot stored")
+	}
+	if !storage.IsPruned(late.ID) {
+		t.Error("message of a pruned slot not reported as pruned")
+	}
+}
+
+func TestStoragePrunedMessagesWindow(t *testing.T) {
+	defer func(horizon int) { config.Params.PruningHorizon = horizon }(config.Params.PruningHorizon)
+	config.Params.PruningHorizon = 1
+
+	genesisTime := time.Now()
+	slotDuration := config.Params.SlotTime * time.Duration(config.Params.SlowdownFactor)
+	storage := NewStorage()
+	storage.Setup(genesisTime)
+
+	old := newTestMessage(1, false)
+	recent := newTestMessage(1, false)
+	recent.IssuanceTime = genesisTime.Add(prunedSlotsWindow * slotDuration)
+	for _, message := range []*Message{old, recent} {
+		if _, stored := storage.Store(message); !stored {
+			t.Fatalf("message %d not stored", message.ID)
+		}
+	}
+
+	storage.ATT = genesisTime.Add((prunedSlotsWindow + 3) * slotDuration)
+	storage.Prune()
+
+	if storage.IsPruned(old.ID) {
+		t.Error("message pruned outside of the window still reported as pruned")
+	}
+	if !storage.IsPruned(recent.ID) {
+		t.Error("message pruned within the window not reported as pruned")
+	}
+	if len(storage.prunedMessages) > prunedSlotsWindow {
+		t.Errorf("expected at most %d pruned slots to be kept, got %d", prunedSlotsWindow, len(storage.prunedMessages))
+	}
+}
+
+func TestSharedMessageStoreChildren(t *testing.T) {
+	messageStore := NewMessageStore()
+	storages := make([]*Storage, 2)
+	for i := range storages {
+		storages[i] = NewStorage()
+		storages[i].messageStore = messageStore
+		storages[i].Setup(time.Now())
+	}
+
+	parent := newTestMessage(1, false)
+	child := newTestMessage(1, false, parent.ID)
+	for _, storage := range storages {
+		storage.Store(parent)
+	}
+	storages[0].Store(child)
+
+	// the children are shared, but every node only sees the children it stored itself
+	if _, exists := storages[0].StrongChildren(parent.ID)[child.ID]; !exists {
+		t.Error("stored child missing")
+	}
+	if len(storages[1].StrongChildren(parent.ID)) != 0 {
+		t.Error("child of another node visible")
+	}
+	if messageStore.MessagesCount() != 2 {
+		t.Errorf("expected 2 messages in the shared store, got %d", messageStore.MessagesCount())
+	}
+
+	storages[1].Store(child)
+	if _, exists := storages[1].StrongChildren(parent.ID)[child.ID]; !exists {
+		t.Error("child missing once the node stored it")
+	}
+}
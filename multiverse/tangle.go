@@ -15,13 +15,21 @@ type Tangle struct {
 	Storage               *Storage
 	Solidifier            *Solidifier
 	ApprovalManager       *ApprovalManager
+	AcceptanceGadget      AcceptanceGadget
 	Requester             *Requester
+	TimestampValidator    *TimestampValidator
+	EquivocationDetector  *EquivocationDetector
 	Booker                *Booker
 	OpinionManager        OpinionManagerInterface
 	TipManager            *TipManager
 	MessageFactory        *MessageFactory
 	Utils                 *Utils
 	Scheduler             Scheduler
+	ManaManager           ManaManager
+	RateSetter            RateSetter
+	SchedulerAnalytics    *SchedulerAnalytics
+	Exporter              *TangleExporter
+	Hooks                 *NodeHooks
 }
 
 func NewTangle() (tangle *Tangle) {
@@ -30,6 +38,8 @@ func NewTangle() (tangle *Tangle) {
 	tangle.Storage = NewStorage()
 	tangle.Solidifier = NewSolidifier(tangle)
 	tangle.Requester = NewRequester(tangle)
+	tangle.TimestampValidator = NewTimestampValidator(tangle)
+	tangle.EquivocationDetector = NewEquivocationDetector(tangle)
 	tangle.Booker = NewBooker(tangle)
 	tangle.OpinionManager = NewOpinionManager(tangle)
 	tangle.TipManager = NewTipManager(tangle, config.Params.TSA)
@@ -37,6 +47,11 @@ func NewTangle() (tangle *Tangle) {
 	tangle.ApprovalManager = NewApprovalManager(tangle)
 	tangle.Utils = NewUtils(tangle)
 	tangle.Scheduler = NewScheduler(tangle)
+	tangle.ManaManager = NewManaManager(tangle)
+	tangle.RateSetter = NewRateSetter(tangle)
+	tangle.SchedulerAnalytics = NewSchedulerAnalytics(tangle)
+	tangle.Exporter = NewTangleExporter(tangle)
+	tangle.Hooks = NewNodeHooks()
 	return
 }
 
@@ -57,10 +72,17 @@ func (t *Tangle) Setup(
 	t.OpinionManager.Setup()
 	t.TipManager.Setup()
 	t.ApprovalManager.Setup()
+	t.AcceptanceGadget = NewAcceptanceGadget(t, acceptanceGadgetName(t))
+	t.AcceptanceGadget.Setup()
+	t.ManaManager.Setup()
 	t.Scheduler.Setup()
+	t.RateSetter.Setup()
+	t.MessageFactory.Setup()
+	t.SchedulerAnalytics.Setup()
 }
 
 func (t *Tangle) ProcessMessage(message *Message) {
+	t.Storage.Prune()
 	if messageMetadata, stored := t.Storage.Store(message); stored {
 		t.Storage.Events.MessageStored.Trigger(message.ID, message, messageMetadata)
 	}
@@ -0,0 +1,51 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// newTestTangle sets up the tangle of node 0 in a network of validatorCount validators with equal weight and bandwidth.
+func newTestTangle(tb testing.TB, validatorCount int) *Tangle {
+	tb.Helper()
+
+	nodesCount, generalOutputDir := config.Params.NodesCount, config.Params.GeneralOutputDir
+	tb.Cleanup(func() { config.Params.NodesCount, config.Params.GeneralOutputDir = nodesCount, generalOutputDir })
+	config.Params.NodesCount = validatorCount
+	// the tip manager of the node writes its confirmation threshold results
+	config.Params.GeneralOutputDir = tb.TempDir()
+
+	weightDistribution := network.NewConsensusWeightDistribution()
+	bandwidthDistribution := network.NewBandwidthDistribution()
+	for i := 0; i < validatorCount; i++ {
+		weightDistribution.SetWeight(network.PeerID(i), 1)
+		bandwidthDistribution.SetBandwidth(network.PeerID(i), 1)
+	}
+
+	node := NewNode().(*Node)
+	peer := network.NewPeer(node)
+	peer.ID = 0
+	peer.SetupNode(weightDistribution, bandwidthDistribution, time.Now())
+
+	return node.Tangle()
+}
+
+// newTestMessage creates a message of one work unit issued by the given node that approves the given parents.
+func newTestMessage(issuer network.PeerID, validation bool, parents ...MessageID) *Message {
+	if len(parents) == 0 {
+		parents = []MessageID{Genesis}
+	}
+	return &Message{
+		Validation:    validation,
+		ID:            NewMessageID(),
+		StrongParents: NewMessageIDs(parents...),
+		WeakParents:   NewMessageIDs(),
+		Issuer:        issuer,
+		Payload:       UndefinedColor,
+		IssuanceTime:  time.Now(),
+		Work:          1,
+	}
+}
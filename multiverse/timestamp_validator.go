@@ -0,0 +1,90 @@
+package multiverse
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/config"
+)
+
+// region TimestampValidator ///////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// TimestampDrift rejects messages whose IssuanceTime is further than TimestampMaxDrift from their arrival.
+	TimestampDrift = "Drift"
+	// TimestampParentOrder rejects messages that are older than one of their parents.
+	TimestampParentOrder = "ParentOrder"
+	// TimestampParentAge rejects messages whose parents are more than TimestampMaxParentAge older than them.
+	TimestampParentAge = "ParentAge"
+)
+
+// TimestampValidator rejects the messages received from the neighbors whose IssuanceTime violates one of the enabled
+// timestamp rules. All rules are disabled by default.
+type TimestampValidator struct {
+	Events *TimestampValidatorEvents
+
+	tangle *Tangle
+}
+
+func NewTimestampValidator(tangle *Tangle) *TimestampValidator {
+	return &TimestampValidator{
+		Events: &TimestampValidatorEvents{
+			MessageRejected: events.NewEvent(messageRejectedEventCaller),
+		},
+		tangle: tangle,
+	}
+}
+
+// Valid returns false and triggers MessageRejected if the message violates one of the enabled timestamp rules.
+func (t *TimestampValidator) Valid(message *Message) bool {
+	if rule, violated := t.violatedRule(message); violated {
+		t.Events.MessageRejected.Trigger(message, rule)
+		return false
+	}
+	return true
+}
+
+func (t *TimestampValidator) violatedRule(message *Message) (rule string, violated bool) {
+	slowdownFactor := time.Duration(config.Params.SlowdownFactor)
+	if maxDrift := config.Params.TimestampMaxDrift * slowdownFactor; maxDrift > 0 {
+		if drift := time.Since(message.IssuanceTime); drift > maxDrift || drift < -maxDrift {
+			return TimestampDrift, true
+		}
+	}
+	if !config.Params.TimestampParentOrder && config.Params.TimestampMaxParentAge <= 0 {
+		return "", false
+	}
+
+	maxParentAge := config.Params.TimestampMaxParentAge * slowdownFactor
+	for _, parents := range []MessageIDs{message.StrongParents, message.WeakParents} {
+		for parentID := range parents {
+			parent := t.tangle.Storage.Message(parentID)
+			// the rules can only be checked for the parents that are known
+			if parentID == Genesis || parent == nil {
+				continue
+			}
+			if config.Params.TimestampParentOrder && message.IssuanceTime.Before(parent.IssuanceTime) {
+				return TimestampParentOrder, true
+			}
+			if maxParentAge > 0 && message.IssuanceTime.Sub(parent.IssuanceTime) > maxParentAge {
+				return TimestampParentAge, true
+			}
+		}
+	}
+	return "", false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TimestampValidatorEvents /////////////////////////////////////////////////////////////////////////////////////
+
+type TimestampValidatorEvents struct {
+	// MessageRejected is triggered with the message and the violated rule.
+	MessageRejected *events.Event
+}
+
+func messageRejectedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Message, string))(params[0].(*Message), params[1].(string))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -68,6 +68,13 @@ func (t *TipManager) Setup() {
 	//t.tangle.OpinionManager.Events().OpinionFormed.Attach(events.NewClosure(t.AnalyzeMessage))
 	// Try "analysing" on scheduling instead of on opinion formation.
 	t.tangle.Scheduler.Events().MessageScheduled.Attach(events.NewClosure(t.AnalyzeMessage))
+	t.tangle.Storage.Events.MessagesPruned.Attach(events.NewClosure(func(messageIDs MessageIDs) {
+		for _, tipSet := range t.tipSets {
+			for messageID := range messageIDs {
+				tipSet.Delete(messageID)
+			}
+		}
+	}))
 }
 
 func (t *TipManager) AnalyzeMessage(messageID MessageID) {
@@ -144,6 +151,7 @@ func (t *TipManager) Tips(validation bool) (strongTips MessageIDs, weakTips Mess
 	} else {
 		strongTips = tipSet.ValidationTips(config.Params.ParentCountVB, config.Params.ParentCountNVB, t.tsa)
 	}
+	strongTips = t.tangle.Hooks.selectTips(strongTips, validation)
 	// In the paper we consider all strong tips
 	// weakTips = tipSet.WeakTips(config.Params.ParentsCount-1, t.tsa)
 
@@ -262,6 +270,15 @@ func (t *TipSet) AddValidatorWeakTip(message *Message) {
 	t.validatorWeakTips.Set(message.ID, message)
 }
 
+// Delete removes the message from all tip pools of the TipSet.
+func (t *TipSet) Delete(messageID MessageID) {
+	t.strongTips.Delete(messageID)
+	t.weakTips.Delete(messageID)
+	t.validatorStrongTips.Delete(messageID)
+	t.validatorValidationTips.Delete(messageID)
+	t.validatorWeakTips.Delete(messageID)
+}
+
 func (t *TipSet) Size() int {
 	return t.strongTips.Size()
 }
@@ -384,16 +401,43 @@ func (RURTS) TipSelect(tips *randommap.RandomMap, maxAmount int) []interface{} {
 
 }
 
+// WalkForOldestUnconfirmed returns the oldest unconfirmed message in the past cone of the strong tips, or Genesis if
+// all of them are confirmed.
 func (t *TipManager) WalkForOldestUnconfirmed(tipSet *TipSet) (oldestMessage MessageID) {
+	return t.walkForUnconfirmed(tipSet, time.Time{})
+}
+
+// WalkForUnconfirmedOfAge returns the unconfirmed message in the past cone of the strong tips whose age is the closest
+// to the given one, or Genesis if all of them are confirmed.
+func (t *TipManager) WalkForUnconfirmedOfAge(tipSet *TipSet, age time.Duration) (message MessageID) {
+	return t.walkForUnconfirmed(tipSet, time.Now().Add(-age))
+}
+
+// walkForUnconfirmed returns the unconfirmed message in the past cone of the strong tips that was issued the closest
+// to the target time and dumps the confirmation ages of the tips on the way.
+func (t *TipManager) walkForUnconfirmed(tipSet *TipSet, targetTime time.Time) (closestMessage MessageID) {
+	closestMessage = Genesis
+	var closestTime time.Time
+	// the distance to a zero target time saturates, so the oldest message is looked for directly
+	closer := func(issuanceTime time.Time) bool {
+		if targetTime.IsZero() {
+			return issuanceTime.Before(closestTime)
+		}
+		return absDuration(issuanceTime.Sub(targetTime)) < absDuration(closestTime.Sub(targetTime))
+	}
 	strongKeys := tipSet.strongTips.Keys()
 
 	for _, tip := range strongKeys {
 		messageID := tip.(MessageID)
+		tipMessage := t.tangle.Storage.Message(messageID)
+		if tipMessage == nil {
+			continue
+		}
 		currentTangleTime := time.Now()
-		tipTangleTime := t.tangle.Storage.Message(messageID).IssuanceTime
+		tipTangleTime := tipMessage.IssuanceTime
 		hasConfirmedParents := false
 
-		for parent := range t.tangle.Storage.Message(messageID).StrongParents {
+		for parent := range tipMessage.StrongParents {
 			if parent == Genesis {
 				continue
 			}
@@ -414,7 +458,10 @@ func (t *TipManager) WalkForOldestUnconfirmed(tipSet *TipSet) (oldestMessage Mes
 				} else {
 					if issuanceTime.Before(oldestUnconfirmedTime) {
 						oldestUnconfirmedTime = issuanceTime
-						oldestMessage = message.ID
+					}
+					if closestMessage == Genesis || closer(issuanceTime) {
+						closestTime = issuanceTime
+						closestMessage = message.ID
 					}
 					// Only continue the BFS when the current block is unconfirmed
 					for strongChildID := range message.StrongParents {
@@ -431,7 +478,15 @@ func (t *TipManager) WalkForOldestUnconfirmed(tipSet *TipSet) (oldestMessage Mes
 			// }
 		}
 	}
-	return 0
+	return
+}
+
+// absDuration returns the absolute value of the duration.
+func absDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return -duration
+	}
+	return duration
 }
 
 func (t *TipManager) dumpAges(hasConfirmedParents bool, currentTangleTime time.Time, oldestUnconfirmedTime time.Time, oldestConfirmationTime time.Time, tipTangleTime time.Time) {
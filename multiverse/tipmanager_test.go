@@ -0,0 +1,41 @@
+package multiverse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWalkForUnconfirmed(t *testing.T) {
+	tangle := newTestTangle(t, 1)
+
+	// a chain of unconfirmed messages that are one second apart, the oldest one approves Genesis
+	now := time.Now()
+	tangle.Storage.Setup(now.Add(-time.Minute))
+	chain := make([]*Message, 4)
+	for i := range chain {
+		if i == 0 {
+			chain[i] = newTestMessage(0, false)
+		} else {
+			chain[i] = newTestMessage(0, false, chain[i-1].ID)
+		}
+		chain[i].IssuanceTime = now.Add(time.Duration(i-len(chain)) * time.Second)
+		if _, stored := tangle.Storage.Store(chain[i]); !stored {
+			t.Fatalf("message %d not stored", chain[i].ID)
+		}
+	}
+	tipSet := NewTipSet(nil)
+	tipSet.AddStrongTip(chain[len(chain)-1])
+
+	if oldest := tangle.TipManager.WalkForOldestUnconfirmed(tipSet); oldest != chain[0].ID {
+		t.Errorf("oldest unconfirmed message %d instead of %d", oldest, chain[0].ID)
+	}
+	if message := tangle.TipManager.WalkForUnconfirmedOfAge(tipSet, 3*time.Second); message != chain[1].ID {
+		t.Errorf("unconfirmed message of age 3s %d instead of %d", message, chain[1].ID)
+	}
+
+	// the walk stops at confirmed messages
+	tangle.Storage.MessageMetadata(chain[1].ID).SetConfirmationTime(now)
+	if oldest := tangle.TipManager.WalkForOldestUnconfirmed(tipSet); oldest != chain[2].ID {
+		t.Errorf("oldest unconfirmed message %d instead of %d above a confirmed one", oldest, chain[2].ID)
+	}
+}
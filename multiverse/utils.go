@@ -36,12 +36,18 @@ func (u *Utils) WalkMessageIDs(callback func(messageID MessageID, walker *walker
 
 func (u *Utils) WalkMessages(callback func(message *Message, walker *walker.Walker), entryPoints MessageIDs, revisitElements ...bool) {
 	u.WalkMessageIDs(func(messageID MessageID, walker *walker.Walker) {
+		if u.tangle.Storage.IsPruned(messageID) {
+			return
+		}
 		callback(u.tangle.Storage.Message(messageID), walker)
 	}, entryPoints, revisitElements...)
 }
 
 func (u *Utils) WalkMessagesAndMetadata(callback func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker), entryPoints MessageIDs, revisitElements ...bool) {
 	u.WalkMessageIDs(func(messageID MessageID, walker *walker.Walker) {
+		if u.tangle.Storage.IsPruned(messageID) {
+			return
+		}
 		callback(u.tangle.Storage.Message(messageID), u.tangle.Storage.MessageMetadata(messageID), walker)
 	}, entryPoints, revisitElements...)
 }
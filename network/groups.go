@@ -1,7 +1,9 @@
 package network
 
 import (
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/iotaledger/hive.go/crypto"
@@ -51,6 +53,16 @@ func AdversaryTypeToString(adv AdversaryType) string {
 	return ""
 }
 
+// adversaryTypeBehaviors are the adversary behaviors of the AdversaryTypes, used for the groups without
+// AdversaryBehaviors. The NoGossip type keeps its original behavior of not issuing any payloads, the messages are only
+// withheld with the NoGossip behavior.
+var adversaryTypeBehaviors = map[AdversaryType][]string{
+	ShiftOpinion:   {"ShiftingOpinion"},
+	TheSameOpinion: {"SameOpinion"},
+	NoGossip:       {"NoIssuance"},
+	Blowball:       {"Blowball"},
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region AdversaryGroup ////////////////////////////////////////////////////////////////////////////////////////////////
@@ -70,6 +82,16 @@ type AdversaryGroup struct {
 	AdversaryType        AdversaryType
 	InitColor            string
 	NodeCount            int
+	// Behaviors are the names of the adversary behaviors that are added to the honest nodes of the group
+	Behaviors []string
+}
+
+// Strategy returns the adversary behaviors of the group joined by '+'.
+func (g *AdversaryGroup) Strategy() string {
+	if len(g.Behaviors) == 0 {
+		return AdversaryTypeToString(HonestNode)
+	}
+	return strings.Join(g.Behaviors, "+")
 }
 
 func (g *AdversaryGroup) AddNodeID(id, groupId int) {
@@ -99,6 +121,11 @@ func NewAdversaryGroups() (groups AdversaryGroups) {
 			nCount = config.Params.AdversaryNodeCounts[i]
 		}
 
+		behaviors := adversaryTypeBehaviors[ToAdversaryType(configAdvType)]
+		if len(config.Params.AdversaryBehaviors) > 0 && config.Params.AdversaryBehaviors[i] != "" {
+			behaviors = strings.Split(config.Params.AdversaryBehaviors[i], "+")
+		}
+
 		color = config.Params.AdversaryInitColors[i]
 		group := &AdversaryGroup{
 			NodeIDs:              make([]int, 0, nCount),
@@ -107,6 +134,7 @@ func NewAdversaryGroups() (groups AdversaryGroups) {
 			AdversaryType:        ToAdversaryType(configAdvType),
 			InitColor:            color,
 			NodeCount:            nCount,
+			Behaviors:            behaviors,
 		}
 		groups = append(groups, group)
 	}
@@ -187,6 +215,128 @@ func (g *AdversaryGroups) ApplyNeighborsAdversaryNodes(network *Network, configu
 	}
 }
 
+// ApplyEclipseVictims replaces the neighbors of every victim by the adversary nodes with the 'Eclipse' behavior, so
+// everything the victims see and forward passes through the adversary.
+func (g *AdversaryGroups) ApplyEclipseVictims(network *Network, configuration *Configuration, victimIDs []int) {
+	eclipseGroups := g.withBehavior("Eclipse")
+	if len(eclipseGroups) == 0 {
+		log.Warnf("EclipseVictims are defined, but there is no adversary group with the 'Eclipse' behavior!")
+		return
+	}
+
+	for _, victimID := range victimIDs {
+		if victimID < 0 || victimID >= len(network.Peers) || IsAdversary(victimID) {
+			log.Warnf("EclipseVictims: %d is not an honest node, so not processed", victimID)
+			continue
+		}
+		victim := network.Peer(victimID)
+		for neighborID, connection := range victim.Neighbors {
+			connection.Shutdown()
+			delete(victim.Neighbors, neighborID)
+		}
+		// with AdversaryPeeringAll the other peers can be connected to the victim in one direction only
+		for _, peer := range network.Peers {
+			if connection, exists := peer.Neighbors[victim.ID]; exists {
+				connection.Shutdown()
+				delete(peer.Neighbors, victim.ID)
+			}
+		}
+		for _, adversaryGroup := range eclipseGroups {
+			for _, nodeID := range adversaryGroup.NodeIDs {
+				adversary := network.Peer(nodeID)
+				victim.Neighbors[adversary.ID] = NewConnection(adversary.Socket, adversaryGroup.Delay, 0, configuration)
+				adversary.Neighbors[victim.ID] = NewConnection(victim.Socket, adversaryGroup.Delay, 0, configuration)
+			}
+		}
+		log.Infof("Eclipsed %s with %d adversary neighbors", victim, len(victim.Neighbors))
+	}
+}
+
+// ApplySybilConnections connects every adversary node with the 'Sybil' behavior to the given number of additional
+// random honest nodes. Every connection takes the slot of one of the honest neighbors of the honest node, as long as
+// both honest nodes keep another honest neighbor.
+func (g *AdversaryGroups) ApplySybilConnections(network *Network, configuration *Configuration, connections int) {
+	sybilGroups := g.withBehavior("Sybil")
+	if len(sybilGroups) == 0 {
+		log.Warnf("SybilConnections are defined, but there is no adversary group with the 'Sybil' behavior!")
+		return
+	}
+
+	honestPeers := make([]*Peer, 0, len(network.Peers))
+	for _, peer := range network.Peers {
+		if !IsAdversary(int(peer.ID)) {
+			honestPeers = append(honestPeers, peer)
+		}
+	}
+	for _, sybilGroup := range sybilGroups {
+		for _, nodeID := range sybilGroup.NodeIDs {
+			sybil := network.Peer(nodeID)
+			connected := 0
+			for _, randomIndex := range crypto.Randomness.Perm(len(honestPeers)) {
+				if connected >= connections {
+					break
+				}
+				target := honestPeers[randomIndex]
+				if _, exists := sybil.Neighbors[target.ID]; exists {
+					continue
+				}
+				replaceHonestNeighbor(network, target)
+				target.Neighbors[sybil.ID] = NewConnection(sybil.Socket, sybilGroup.Delay, 0, configuration)
+				sybil.Neighbors[target.ID] = NewConnection(target.Socket, sybilGroup.Delay, 0, configuration)
+				connected++
+			}
+		}
+	}
+}
+
+// replaceHonestNeighbor frees a neighbor slot of the peer by removing the link to one of its honest neighbors, if
+// both of them keep another honest neighbor.
+func replaceHonestNeighbor(network *Network, peer *Peer) {
+	honestNeighbors := honestNeighborIDs(peer)
+	if len(honestNeighbors) < 2 {
+		return
+	}
+	for _, randomIndex := range crypto.Randomness.Perm(len(honestNeighbors)) {
+		neighbor := network.Peer(int(honestNeighbors[randomIndex]))
+		if len(honestNeighborIDs(neighbor)) < 2 {
+			continue
+		}
+		peer.Neighbors[neighbor.ID].Shutdown()
+		delete(peer.Neighbors, neighbor.ID)
+		if connection, exists := neighbor.Neighbors[peer.ID]; exists {
+			connection.Shutdown()
+			delete(neighbor.Neighbors, peer.ID)
+		}
+		return
+	}
+}
+
+// honestNeighborIDs returns the sorted IDs of the honest neighbors of the peer.
+func honestNeighborIDs(peer *Peer) []PeerID {
+	honestNeighbors := make([]PeerID, 0, len(peer.Neighbors))
+	for neighborID := range peer.Neighbors {
+		if !IsAdversary(int(neighborID)) {
+			honestNeighbors = append(honestNeighbors, neighborID)
+		}
+	}
+	sort.Slice(honestNeighbors, func(i, j int) bool { return honestNeighbors[i] < honestNeighbors[j] })
+	return honestNeighbors
+}
+
+// withBehavior returns the adversary groups that have the given behavior.
+func (g *AdversaryGroups) withBehavior(name string) []*AdversaryGroup {
+	groups := make([]*AdversaryGroup, 0)
+	for _, adversaryGroup := range *g {
+		for _, behavior := range adversaryGroup.Behaviors {
+			if behavior == name {
+				groups = append(groups, adversaryGroup)
+				break
+			}
+		}
+	}
+	return groups
+}
+
 func randomWeightIndex(weights []uint64, count int) (randomWeights []int) {
 	selectedPeers := set.New()
 	for len(randomWeights) < count {
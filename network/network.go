@@ -74,6 +74,8 @@ type Configuration struct {
 	peeringStrategy     PeeringStrategy
 	adversaryPeeringAll bool
 	adversarySpeedup    []float64
+	eclipseVictims      []int
+	sybilConnections    int
 	genesisTime         time.Time
 }
 
@@ -110,17 +112,19 @@ func (c *Configuration) CreatePeers(network *Network) {
 		nodeBandwidth := nodesSpecification.ConfigureBandwidth(network)
 
 		for i := 0; i < nodesSpecification.nodeCount; i++ {
-			nodeType := HonestNode
+			nodeFactory := nodesSpecification.nodeFactory
 			speedupFactor := 1.0
 			// this is adversary node
 			if groupIndex, ok := AdversaryNodeIDToGroupIDMap[i]; ok {
-				nodeType = network.AdversaryGroups[groupIndex].AdversaryType
+				nodeFactory = nodesSpecification.adversaryFactory.forGroup(network.AdversaryGroups[groupIndex])
 				speedupFactor = c.adversarySpeedup[groupIndex]
 			}
 			if IsAttacker(i) {
-				nodeType = Blowball
+				nodeFactory = nodesSpecification.adversaryFactory.forGroup(&AdversaryGroup{
+					AdversaryType: Blowball,
+					Behaviors:     adversaryTypeBehaviors[Blowball],
+				})
 			}
-			nodeFactory := nodesSpecification.nodeFactories[nodeType]
 
 			peer := NewPeer(nodeFactory())
 			peer.AdversarySpeedup = speedupFactor
@@ -149,6 +153,12 @@ func (c *Configuration) ConnectPeers(network *Network) {
 	if c.adversaryPeeringAll {
 		network.AdversaryGroups.ApplyNeighborsAdversaryNodes(network, c)
 	}
+	if len(c.eclipseVictims) > 0 {
+		network.AdversaryGroups.ApplyEclipseVictims(network, c, c.eclipseVictims)
+	}
+	if c.sybilConnections > 0 {
+		network.AdversaryGroups.ApplySybilConnections(network, c, c.sybilConnections)
+	}
 	network.AdversaryGroups.ApplyNetworkDelayForAdversaryNodes(network)
 
 }
@@ -160,13 +170,15 @@ func (c *Configuration) ConnectPeers(network *Network) {
 type Option func(*Configuration)
 
 func Nodes(nodeCount int,
-	nodeFactories map[AdversaryType]NodeFactory,
+	nodeFactory NodeFactory,
+	adversaryFactory AdversaryNodeFactory,
 	weightGenerator WeightGenerator,
 	bandwidthGenerator BandwidthGenerator,
 ) Option {
 	nodeSpecs := &NodesSpecification{
 		nodeCount:          nodeCount,
-		nodeFactories:      nodeFactories,
+		nodeFactory:        nodeFactory,
+		adversaryFactory:   adversaryFactory,
 		weightGenerator:    weightGenerator,
 		bandwidthGenerator: bandwidthGenerator,
 	}
@@ -178,7 +190,8 @@ func Nodes(nodeCount int,
 
 type NodesSpecification struct {
 	nodeCount          int
-	nodeFactories      map[AdversaryType]NodeFactory
+	nodeFactory        NodeFactory
+	adversaryFactory   AdversaryNodeFactory
 	weightGenerator    WeightGenerator
 	bandwidthGenerator BandwidthGenerator
 }
@@ -258,6 +271,18 @@ func AdversarySpeedup(adversarySpeedupFactors []float64) Option {
 	}
 }
 
+func EclipseVictims(victimIDs []int) Option {
+	return func(config *Configuration) {
+		config.eclipseVictims = victimIDs
+	}
+}
+
+func SybilConnections(connections int) Option {
+	return func(config *Configuration) {
+		config.sybilConnections = connections
+	}
+}
+
 func GenesisTime(genesisTime time.Time) Option {
 	return func(config *Configuration) {
 		config.genesisTime = genesisTime
@@ -10,6 +10,15 @@ type Node interface {
 
 type NodeFactory func() Node
 
+// AdversaryNodeFactory creates the nodes of an adversary group.
+type AdversaryNodeFactory func(group *AdversaryGroup) Node
+
+func (a AdversaryNodeFactory) forGroup(group *AdversaryGroup) NodeFactory {
+	return func() Node {
+		return a(group)
+	}
+}
+
 func NodeClosure(closure func() interface{}) NodeFactory {
 	return func() Node {
 		return closure().(Node)
@@ -18,6 +18,8 @@ type Peer struct {
 	Socket           chan interface{}
 	Node             Node
 	AdversarySpeedup float64
+	// GossipFilter decides whether a network message is sent to a neighbor, nil sends everything to all neighbors.
+	GossipFilter GossipFilter
 
 	shutdownOnce       sync.Once
 	ShutdownProcessing chan struct{}
@@ -54,8 +56,10 @@ func (p *Peer) ReceiveNetworkMessage(message interface{}) {
 }
 
 func (p *Peer) GossipNetworkMessage(message interface{}) {
-	for _, neighborConnection := range p.Neighbors {
-		neighborConnection.Send(message)
+	for neighborID, neighborConnection := range p.Neighbors {
+		if p.GossipFilter == nil || p.GossipFilter(neighborID, message) {
+			neighborConnection.Send(message)
+		}
 	}
 }
 
@@ -63,6 +67,9 @@ func (p *Peer) String() string {
 	return fmt.Sprintf("Peer%d", p.ID)
 }
 
+// GossipFilter decides whether a network message is gossiped to the given neighbor.
+type GossipFilter func(neighbor PeerID, networkMessage interface{}) bool
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region PeerID ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -7,6 +7,7 @@ import (
 
 type SingleAttacker struct {
 	nodeID               int
+	nodeCount            int
 	TargetManaPercentage int
 	AttackerType         AdversaryType
 	weight               float64
@@ -14,23 +15,29 @@ type SingleAttacker struct {
 
 func (a SingleAttacker) CalculateWeightTotalConfig() (newNodesCount int, newTotalWeight float64) {
 	newTotalWeight = float64(config.Params.NodesTotalWeight) - a.weight
-	newNodesCount = config.Params.NodesCount - 1
+	newNodesCount = config.Params.NodesCount - a.nodeCount
 	return
 }
 func insert[V constraints.Numeric](array []V, element V, i int) []V {
-	array = append(array[:i+1], array[i:]...)
+	array = append(array, element)
+	copy(array[i+1:], array[i:])
 	array[i] = element
 	return array
 }
 
+// UpdateAttackerWeight splits the weight of the attackers evenly among them and inserts it at their node IDs.
 func (a SingleAttacker) UpdateAttackerWeight(weights []uint64) []uint64 {
-	return insert(weights, uint64(a.weight), a.nodeID)
+	for i := 0; i < a.nodeCount; i++ {
+		weights = insert(weights, uint64(a.weight/float64(a.nodeCount)), a.nodeID+i)
+	}
+	return weights
 }
 
 func NewSingleAttacker() *SingleAttacker {
 	return &SingleAttacker{
 		weight:               float64(config.Params.BlowballMana) * float64(config.Params.NodesTotalWeight) / 100,
 		nodeID:               config.Params.BlowballNodeID,
+		nodeCount:            config.Params.BlowballNodeCount,
 		TargetManaPercentage: config.Params.BlowballMana,
 		AttackerType:         Blowball,
 	}
@@ -49,7 +56,8 @@ func NewSingleAttacker() *SingleAttacker {
 //	return node.(T)
 //}
 
+// IsAttacker returns true if the node is one of the blowball nodes of the 'Blowball' SimulationMode.
 func IsAttacker(nodeID int) bool {
-	// return nodeID == config.Params.BlowballNodeID
-	return false
+	return config.Params.SimulationMode == "Blowball" &&
+		nodeID >= config.Params.BlowballNodeID && nodeID < config.Params.BlowballNodeID+config.Params.BlowballNodeCount
 }
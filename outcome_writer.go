@@ -0,0 +1,21 @@
+package main
+
+import (
+	"encoding/csv"
+)
+
+// csvRows are the records of a CSV file without its header.
+type csvRows [][]string
+
+// writeCSV writes the header and the rows of the outcome of a simulation to the CSV file at the given path.
+func writeCSV(filePath string, header []string, rows csvRows) {
+	file, err := createFile(filePath)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	if err := csv.NewWriter(file).WriteAll(append(csvRows{header}, rows...)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region parasite chain ///////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	parasiteOutcome      = &parasiteChainOutcome{withheld: make(map[int]int), sideTangleConfirmations: make(map[int]int64)}
+	parasiteOutcomeMutex sync.Mutex
+)
+
+// parasiteChainOutcome tracks the side-tangles of the 'ParasiteChain' attackers and the confirmed colors of the honest
+// nodes around their release.
+type parasiteChainOutcome struct {
+	// withheld is the number of withheld messages released by every parasite chain
+	withheld map[int]int
+	// sideTangleConfirmations is the number of confirmations of the messages of every side-tangle by honest nodes
+	sideTangleConfirmations map[int]int64
+
+	// honestConfirmedWeight is the weight of the honest nodes that currently have a confirmed color
+	honestConfirmedWeight          int64
+	releaseTime                    time.Time
+	confirmedWeightAtRelease       int64
+	minConfirmedWeightAfterRelease int64
+	unconfirmationsAfterRelease    int64
+}
+
+// monitorParasiteChains records the release of the side-tangles, how the honest nodes confirm their messages and how
+// the confirmed colors of the honest nodes regress after the first release.
+func monitorParasiteChains(net *network.Network) {
+	parasiteChains := adversary.ParasiteChains()
+	if len(parasiteChains) == 0 {
+		return
+	}
+
+	for i, parasiteChain := range parasiteChains {
+		chainIndex := i
+		parasiteChain.Events.Released.Attach(events.NewClosure(func(withheldMessages int) {
+			parasiteOutcomeMutex.Lock()
+			defer parasiteOutcomeMutex.Unlock()
+			parasiteOutcome.withheld[chainIndex] = withheldMessages
+			if parasiteOutcome.releaseTime.IsZero() {
+				parasiteOutcome.releaseTime = time.Now()
+				parasiteOutcome.confirmedWeightAtRelease = parasiteOutcome.honestConfirmedWeight
+				parasiteOutcome.minConfirmedWeightAfterRelease = parasiteOutcome.honestConfirmedWeight
+			}
+		}))
+	}
+
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		tangle.OpinionManager.Events().ColorConfirmed.Attach(events.NewClosure(func(confirmedColor multiverse.Color, weight int64) {
+			parasiteOutcomeMutex.Lock()
+			defer parasiteOutcomeMutex.Unlock()
+			parasiteOutcome.honestConfirmedWeight += weight
+		}))
+		tangle.OpinionManager.Events().ColorUnconfirmed.Attach(events.NewClosure(func(unconfirmedColor multiverse.Color, unconfirmedSupport int64, weight int64) {
+			parasiteOutcomeMutex.Lock()
+			defer parasiteOutcomeMutex.Unlock()
+			parasiteOutcome.honestConfirmedWeight -= weight
+			if parasiteOutcome.releaseTime.IsZero() {
+				return
+			}
+			parasiteOutcome.unconfirmationsAfterRelease++
+			if parasiteOutcome.honestConfirmedWeight < parasiteOutcome.minConfirmedWeightAfterRelease {
+				parasiteOutcome.minConfirmedWeightAfterRelease = parasiteOutcome.honestConfirmedWeight
+			}
+		}))
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			for chainIndex, parasiteChain := range parasiteChains {
+				if parasiteChain.Contains(message.ID) {
+					parasiteOutcomeMutex.Lock()
+					parasiteOutcome.sideTangleConfirmations[chainIndex]++
+					parasiteOutcomeMutex.Unlock()
+				}
+			}
+		}))
+	}
+}
+
+// dumpParasiteChains writes the weight every parasite chain attacker had and the size of its side-tangle, next to the
+// regression of the confirmed weight of the honest nodes after the first release.
+func dumpParasiteChains(net *network.Network) {
+	parasiteChains := adversary.ParasiteChains()
+	if len(parasiteChains) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Weight",
+		"Weight Share",
+		"Side-Tangle Messages",
+		"Withheld Messages",
+		"Release Time Since Start (ns)",
+		"Honest Side-Tangle Confirmations",
+		"Honest Confirmed Weight At Release",
+		"Min Honest Confirmed Weight After Release",
+		"Honest Confirmed Weight At End",
+		"Honest Unconfirmations After Release",
+	}
+	rows := make(csvRows, 0)
+
+	parasiteOutcomeMutex.Lock()
+	defer parasiteOutcomeMutex.Unlock()
+	for chainIndex, parasiteChain := range parasiteChains {
+		peerID := parasiteChain.Node().Peer().ID
+		weight := net.WeightDistribution.Weight(peerID)
+		_, sideTangleMessages, _, releaseTime := parasiteChain.Stats()
+		releaseTimeSinceStart := int64(-1)
+		if !releaseTime.IsZero() {
+			releaseTimeSinceStart = releaseTime.Sub(simulationStartTime).Nanoseconds()
+		}
+		record := []string{
+			strconv.FormatInt(int64(peerID), 10),
+			strconv.FormatUint(weight, 10),
+			strconv.FormatFloat(float64(weight)/float64(net.WeightDistribution.TotalWeight()), 'f', 6, 64),
+			strconv.Itoa(sideTangleMessages),
+			strconv.Itoa(parasiteOutcome.withheld[chainIndex]),
+			strconv.FormatInt(releaseTimeSinceStart, 10),
+			strconv.FormatInt(parasiteOutcome.sideTangleConfirmations[chainIndex], 10),
+			strconv.FormatInt(parasiteOutcome.confirmedWeightAtRelease, 10),
+			strconv.FormatInt(parasiteOutcome.minConfirmedWeightAfterRelease, 10),
+			strconv.FormatInt(parasiteOutcome.honestConfirmedWeight, 10),
+			strconv.FormatInt(parasiteOutcome.unconfirmationsAfterRelease, 10),
+		}
+		rows = append(rows, record)
+	}
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "parasiteChains.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/csv"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region scheduling ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dumpSchedulerAnalytics writes the fairness index of every node and the deficits of all issuers at every node.
+func dumpSchedulerAnalytics(net *network.Network, fairnessWriter, deficitWriter *csv.Writer) {
+	timeStr := strconv.FormatInt(time.Since(simulationStartTime).Nanoseconds(), 10)
+	record := make([]string, config.Params.NodesCount+1)
+	for _, peer := range net.Peers {
+		record[peer.ID] = strconv.FormatFloat(peer.Node.(multiverse.NodeInterface).Tangle().SchedulerAnalytics.FairnessIndex(), 'f', 6, 64)
+	}
+	record[config.Params.NodesCount] = timeStr
+	if err := fairnessWriter.Write(record); err != nil {
+		panic(err)
+	}
+	fairnessWriter.Flush()
+
+	for _, peer := range net.Peers {
+		record = make([]string, config.Params.NodesCount+2)
+		record[0] = strconv.FormatInt(int64(peer.ID), 10)
+		for id := 0; id < config.Params.NodesCount; id++ {
+			record[id+1] = strconv.FormatFloat(peer.Node.(multiverse.NodeInterface).Tangle().Scheduler.Deficit(network.PeerID(id)), 'f', 6, 64)
+		}
+		record[config.Params.NodesCount+1] = timeStr
+		if err := deficitWriter.Write(record); err != nil {
+			panic(err)
+		}
+	}
+	deficitWriter.Flush()
+}
+
+// dumpSchedulingDelays writes the scheduling delays and non-ready queueing times of the data messages per node and
+// issuer.
+func dumpSchedulingDelays(net *network.Network) {
+	header := []string{
+		"Node ID",
+		"Issuer ID",
+		"Scheduled Messages",
+		"Dropped Messages",
+		"Mean Scheduling Delay (ns)",
+		"Max Scheduling Delay (ns)",
+		"Non-Ready Messages",
+		"Mean Non-Ready Time (ns)",
+	}
+	rows := make(csvRows, 0)
+	for _, peer := range net.Peers {
+		issuerStats := peer.Node.(multiverse.NodeInterface).Tangle().SchedulerAnalytics.IssuerStats()
+		for id := 0; id < config.Params.NodesCount; id++ {
+			stats, exists := issuerStats[network.PeerID(id)]
+			if !exists {
+				continue
+			}
+			meanDelay, meanNonReadyTime := int64(0), int64(0)
+			if stats.ScheduledMessages > 0 {
+				meanDelay = stats.SchedulingDelay.Nanoseconds() / int64(stats.ScheduledMessages)
+			}
+			if stats.NonReadyMessages > 0 {
+				meanNonReadyTime = stats.NonReadyTime.Nanoseconds() / int64(stats.NonReadyMessages)
+			}
+			record := []string{
+				strconv.FormatInt(int64(peer.ID), 10),
+				strconv.Itoa(id),
+				strconv.Itoa(stats.ScheduledMessages),
+				strconv.Itoa(stats.DroppedMessages),
+				strconv.FormatInt(meanDelay, 10),
+				strconv.FormatInt(stats.MaxSchedulingDelay.Nanoseconds(), 10),
+				strconv.Itoa(stats.NonReadyMessages),
+				strconv.FormatInt(meanNonReadyTime, 10),
+			}
+			rows = append(rows, record)
+		}
+	}
+	writeCSV(path.Join(config.Params.SchedulerOutputDir, "schedulingDelays.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -72,6 +72,8 @@ class Configuration:
 
         # Adversary strategies
         self.cd["ADVERSARY_STRATEGY"] = "1 1"
+        # Adversary behaviors of each group joined by '+', overrides the strategies if not empty
+        self.cd["ADVERSARY_BEHAVIORS"] = ""
 
         # Plotting variation setting
         self.cd['PLOT_VARIED_FIGURES'] = False
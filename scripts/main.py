@@ -95,6 +95,9 @@ def parse_arg():
     parser.add_argument("-as", "--ADVERSARY_STRATEGY", dest='ADVERSARY_STRATEGY',
                         help="Adversary types",
                         default=config.cd['ADVERSARY_STRATEGY'])
+    parser.add_argument("-ab", "--ADVERSARY_BEHAVIORS", dest='ADVERSARY_BEHAVIORS',
+                        help="Adversary behaviors of each group joined by '+'",
+                        default=config.cd['ADVERSARY_BEHAVIORS'])
     parser.add_argument("-nc", "--NODES_COUNT", dest='NODES_COUNT',
                         help="Nodes count",
                         default=config.cd['NODES_COUNT'])
@@ -142,6 +145,7 @@ if __name__ == '__main__':
     base_folder = f'{result_path}/var_{var}_{target}'
     repetition = config.cd['REPETITION_TIME']
     adv_strategy = config.cd['ADVERSARY_STRATEGY']
+    adv_behaviors = config.cd['ADVERSARY_BEHAVIORS']
 
     # Generate the folders if they don't exist
     os.makedirs(result_path, exist_ok=True)
@@ -185,7 +189,7 @@ if __name__ == '__main__':
                 for i, v in enumerate(vv):
                     v = str(float(v)/2)
                     os.system(
-                        f'{exec} --simulationTarget={target}  -simulationMode=Adversary -adversaryMana="{v} {v}" -adversaryType="{adv_strategy}" -adversaryInitColors="R B" -slowdownFactor={df[i]}')
+                        f'{exec} --simulationTarget={target}  -simulationMode=Adversary -adversaryMana="{v} {v}" -adversaryType="{adv_strategy}" -adversaryBehaviors="{adv_behaviors}" -adversaryInitColors="R B" -slowdownFactor={df[i]}')
             elif var == 'AC':
                 for i, v in enumerate(vv):
                     if "adversaryMana" not in exec:
@@ -20,6 +20,12 @@ func ParseFlags() {
 	// Define the configuration flags
 	nodesCountPtr :=
 		flag.Int("nodesCount", config.Params.NodesCount, "The number of nodes")
+	validatorCountPtr :=
+		flag.Int("validatorCount", config.Params.ValidatorCount, "The number of validator nodes")
+	validatorBPSPtr :=
+		flag.Int("validatorBPS", config.Params.ValidatorBPS, "The rate of validation blocks per validator node")
+	adaptiveValidatorIssuancePtr :=
+		flag.Bool("adaptiveValidatorIssuance", config.Params.AdaptiveValidatorIssuance, "Skip validation blocks if no new data message was scheduled since the last one")
 	nodesTotalWeightPtr :=
 		flag.Int("nodesTotalWeight", config.Params.NodesTotalWeight, "The total weight of nodes")
 	zipfParameterPtr :=
@@ -28,6 +34,28 @@ func ParseFlags() {
 		flag.Float64("confirmationThreshold", config.Params.ConfirmationThreshold, "The confirmationThreshold of confirmed messages/color")
 	confirmationThresholdAbsolutePtr :=
 		flag.Bool("confirmationThresholdAbsolute", config.Params.ConfirmationThresholdAbsolute, "If set to false, the weight is counted by subtracting AW of the two largest conflicting branches.")
+	approvalWeightEnginePtr :=
+		flag.String("approvalWeightEngine", config.Params.ApprovalWeightEngine, "The approval weight propagation engine: 'Walk' (full past cone walk) or 'Indexed' (stops at decided messages, which then keep the weight they had when they were decided)")
+	acceptanceGadgetPtr :=
+		flag.String("acceptanceGadget", config.Params.AcceptanceGadget, "The finality gadget of validator nodes: 'Threshold', 'Depth', 'ValidatorK' or 'TwoPhase'")
+	nonValidatorAcceptanceGadgetPtr :=
+		flag.String("nonValidatorAcceptanceGadget", config.Params.NonValidatorAcceptanceGadget, "The finality gadget of non-validator nodes, empty to use acceptanceGadget")
+	confirmationDepthPtr :=
+		flag.Int("confirmationDepth", config.Params.ConfirmationDepth, "The number of messages on top of a message needed by the 'Depth' gadget")
+	validatorConfirmationsPtr :=
+		flag.Int("validatorConfirmations", config.Params.ValidatorConfirmations, "The number of distinct validators approving a message needed by the 'ValidatorK' gadget")
+	acceptanceThresholdPtr :=
+		flag.Float64("acceptanceThreshold", config.Params.AcceptanceThreshold, "The AW threshold of the acceptance phase of the 'TwoPhase' gadget")
+	orphanageRulePtr :=
+		flag.String("orphanageRule", config.Params.OrphanageRule, "The orphanage rule: 'TooOld' (issued before ATT - MinCommittableAge) or 'None'")
+	timestampMaxDriftPtr :=
+		flag.Duration("timestampMaxDrift", config.Params.TimestampMaxDrift, "Reject received messages whose issuance time is further than this from their arrival, 0 disables the rule")
+	timestampParentOrderPtr :=
+		flag.Bool("timestampParentOrder", config.Params.TimestampParentOrder, "Reject received messages that are older than one of their parents")
+	timestampMaxParentAgePtr :=
+		flag.Duration("timestampMaxParentAge", config.Params.TimestampMaxParentAge, "Reject received messages whose parents are more than this older than them, 0 disables the rule")
+	equivocationPolicyPtr :=
+		flag.String("equivocationPolicy", config.Params.EquivocationPolicy, "How detected equivocators are treated, one of: 'None', 'Ignore', 'Slash'")
 	parentsCountPtr :=
 		flag.Int("parentsCount", config.Params.ParentsCount, "The parents count for a message")
 	weakTipsRatioPtr :=
@@ -42,10 +70,62 @@ func ParseFlags() {
 		flag.Int("monitoredWitnessWeightMessageID", config.Params.MonitoredWitnessWeightMessageID, "The message for which we monitor the WW growth")
 	simulationDurationPtr :=
 		flag.Duration("simulationDuration", config.Params.SimulationDuration, "The simulation time of the experiment")
+	pruningHorizonPtr :=
+		flag.Int("pruningHorizon", config.Params.PruningHorizon, "The number of slots behind the ATT kept in the storage of every node, 0 disables pruning")
+	streamGlobalMetricsPtr :=
+		flag.Bool("streamGlobalMetrics", config.Params.StreamGlobalMetrics, "Write the per-message results as soon as they are final instead of keeping the messages in memory")
+	sharedMessageStorePtr :=
+		flag.Bool("sharedMessageStore", config.Params.SharedMessageStore, "Keep a single copy of every message for all nodes instead of one per node")
+	exportFormatsPtr :=
+		flag.String("exportFormats", "", "The formats the tangle of exportNodeID is exported in at shutdown or on SIGUSR1, e.g. 'dot graphml json'")
+	exportNodeIDPtr :=
+		flag.Int("exportNodeID", config.Params.ExportNodeID, "The node whose tangle is exported")
+	exportStartSlotPtr :=
+		flag.Int("exportStartSlot", config.Params.ExportStartSlot, "The first slot of the exported tangle, -1 is unbounded")
+	exportEndSlotPtr :=
+		flag.Int("exportEndSlot", config.Params.ExportEndSlot, "The last slot of the exported tangle, -1 is unbounded")
+	exportStartTimePtr :=
+		flag.Duration("exportStartTime", config.Params.ExportStartTime, "The time since the genesis of the first message of the exported tangle, 0 is unbounded")
+	exportEndTimePtr :=
+		flag.Duration("exportEndTime", config.Params.ExportEndTime, "The time since the genesis of the last message of the exported tangle, 0 is unbounded")
 	schedulerTypePtr :=
 		flag.String("schedulerType", config.Params.SchedulerType, "The type of the scheduler.")
+	manaManagerTypePtr :=
+		flag.String("manaManagerType", config.Params.ManaManagerType, "The mana accounting: ManaBurn, ICCA+, Stake or None, empty to use the one of the scheduler")
+	rateSetterTypePtr :=
+		flag.String("rateSetterType", config.Params.RateSetterType, "The rate setter: ICCA+, AIMD, TokenBucket or None, empty to use the one of the scheduler")
+	aimdIncreasePtr :=
+		flag.Float64("aimdIncrease", config.Params.AIMDIncrease, "The additive increase of the AIMD rate setter in work units per second per second")
+	aimdDecreasePtr :=
+		flag.Float64("aimdDecrease", config.Params.AIMDDecrease, "The multiplicative decrease of the AIMD rate setter")
+	aimdMinRatePtr :=
+		flag.Float64("aimdMinRate", config.Params.AIMDMinRate, "The minimum rate of the AIMD rate setter in work units per second")
+	aimdQueueThresholdPtr :=
+		flag.Int("aimdQueueThreshold", config.Params.AIMDQueueThreshold, "The work of the own issuer queue above which the AIMD rate setter decreases the rate")
+	aimdHoldTimePtr :=
+		flag.Duration("aimdHoldTime", config.Params.AIMDHoldTime, "The time after a decrease of the AIMD rate setter during which the rate is not decreased again")
+	tokenBucketSizePtr :=
+		flag.Float64("tokenBucketSize", config.Params.TokenBucketSize, "The size of the bucket of the TokenBucket rate setter in work units")
 	schedulingRate :=
-		flag.Int("schedulingRate", config.Params.SchedulingRate, "The scheduling rate of the scheduler in message per second.")
+		flag.Int("schedulingRate", config.Params.SchedulingRate, "The scheduling rate of the scheduler in work units per second.")
+	workDistributionPtr :=
+		flag.String("workDistribution", config.Params.WorkDistribution, "The distribution of the work of data messages: Constant or Uniform")
+	minWorkPtr :=
+		flag.Int("minWork", config.Params.MinWork, "The work of data messages, the lower bound for the Uniform distribution")
+	maxWorkPtr :=
+		flag.Int("maxWork", config.Params.MaxWork, "The upper bound of the work of data messages for the Uniform distribution")
+	validationWorkPtr :=
+		flag.Int("validationWork", config.Params.ValidationWork, "The work of validation blocks")
+	coloredWorkPtr :=
+		flag.Int("coloredWork", config.Params.ColoredWork, "The work of messages carrying a color, 0 to use the work distribution")
+	maxBufferPtr :=
+		flag.Int("maxBuffer", config.Params.MaxBuffer, "The maximum work of the ready messages in the scheduler buffer")
+	dropPolicyPtr :=
+		flag.String("dropPolicy", config.Params.DropPolicy, "The buffer drop policy: Head, Tail, HeadFromLongest, NewestFromLongest, Burn or RED, empty for the default of the scheduler")
+	redThresholdPtr :=
+		flag.Float64("redThreshold", config.Params.REDThreshold, "The buffer load relative to maxBuffer above which RED starts dropping")
+	redMaxProbabilityPtr :=
+		flag.Float64("redMaxProbability", config.Params.REDMaxProbability, "The drop probability of RED when the buffer is full")
 	maxDeficitPtr :=
 		flag.Float64("maxDeficit", config.Params.MaxDeficit, "The maximum deficit for all nodes")
 	slotTimePtr :=
@@ -74,6 +154,50 @@ func ParseFlags() {
 		flag.Float64("rmcDecrease", config.Params.RMCdecrease, "The RMC value to decrease")
 	rmcPeriodUpdatePtr :=
 		flag.Int("rmcPeriodUpdate", config.Params.RMCPeriodUpdate, "The period to update RMC")
+	rmcControllerPtr :=
+		flag.String("rmcController", config.Params.RMCController, "The RMC update rule: Additive, Multiplicative, PID or EIP1559")
+	rmcTargetTrafficPtr :=
+		flag.Float64("rmcTargetTraffic", config.Params.RMCTargetTraffic, "The accepted messages per slot targeted by the PID and EIP1559 controllers, 0 for the middle of the thresholds")
+	rmcKpPtr :=
+		flag.Float64("rmcKp", config.Params.RMCKp, "The proportional gain of the PID RMC controller")
+	rmcKiPtr :=
+		flag.Float64("rmcKi", config.Params.RMCKi, "The integral gain of the PID RMC controller")
+	rmcKdPtr :=
+		flag.Float64("rmcKd", config.Params.RMCKd, "The derivative gain of the PID RMC controller")
+	rmcAdjustmentQuotientPtr :=
+		flag.Float64("rmcAdjustmentQuotient", config.Params.RMCAdjustmentQuotient, "The adjustment quotient of the EIP1559 RMC controller, at least 1")
+	manaGenerationRatePtr :=
+		flag.Float64("manaGenerationRate", config.Params.ManaGenerationRate, "The mana generated per slot by every unit of stake of the Stake mana manager")
+	manaDecayPtr :=
+		flag.Float64("manaDecay", config.Params.ManaDecay, "The fraction of the mana of every account that decays per slot")
+	manaCapPtr :=
+		flag.Float64("manaCap", config.Params.ManaCap, "The maximum mana of an account, 0 for no cap")
+	manaTransferProbabilityPtr :=
+		flag.Float64("manaTransferProbability", config.Params.ManaTransferProbability, "The probability that a data message transfers mana to a random node")
+	manaTransferFractionPtr :=
+		flag.Float64("manaTransferFraction", config.Params.ManaTransferFraction, "The fraction of the own mana transferred by a mana transfer")
+	manaAllocationFilePtr :=
+		flag.String("manaAllocationFile", config.Params.ManaAllocationFile, "The CSV file with the columns 'Node ID,Stake,Mana' of the initial mana allocation")
+	manaLedgerModePtr :=
+		flag.String("manaLedgerMode", config.Params.ManaLedgerMode, "The mana accounting of the nodes: Local or Committed")
+	burnHistorySizePtr :=
+		flag.Int("burnHistorySize", config.Params.BurnHistorySize, "The number of recently scheduled burns the fee estimation burn policy uses")
+	burnFeePercentilePtr :=
+		flag.Float64("burnFeePercentile", config.Params.BurnFeePercentile, "The percentile of the recently scheduled burns burnt by the fee estimation burn policy")
+	burnDeadlinePtr :=
+		flag.Duration("burnDeadline", config.Params.BurnDeadline, "The time without an own scheduled message after which the deadline burn policy burns all mana")
+	burnRandomGreedyMaxPtr :=
+		flag.Float64("burnRandomGreedyMax", config.Params.BurnRandomGreedyMax, "The maximum amount the random greedy burn policy outbids the highest burn with")
+	burnBudgetPtr :=
+		flag.Float64("burnBudget", config.Params.BurnBudget, "The mana the budget burn policy burns at most within the budget window")
+	burnBudgetWindowPtr :=
+		flag.Duration("burnBudgetWindow", config.Params.BurnBudgetWindow, "The time window of the budget burn policy")
+	validationSchedulingPtr :=
+		flag.String("validationScheduling", config.Params.ValidationScheduling, "The scheduling of validation blocks by the ICCA+ scheduler: Bypass or Lane")
+	validationLaneRatePtr :=
+		flag.Float64("validationLaneRate", config.Params.ValidationLaneRate, "The rate of validation blocks per second the validation lane schedules at most")
+	validationLaneBurstPtr :=
+		flag.Float64("validationLaneBurst", config.Params.ValidationLaneBurst, "The number of validation blocks the validation lane can schedule in a burst")
 	issuingRatePtr :=
 		flag.Int("issuingRate", config.Params.IssuingRate, "the tips per seconds")
 	slowdownFactorPtr :=
@@ -116,16 +240,80 @@ func ParseFlags() {
 		flag.String("adversaryInitColors", "", "Defines initial color for adversary group, one of following: 'R', 'G', 'B'. Mandatory for each group. SimulationTarget must be 'DS'")
 	adversaryMana :=
 		flag.String("adversaryMana", "", "Adversary nodes mana in %, e.g. '10 10' Special values: -1 nodes should be selected randomly from weight distribution, SimulationTarget must be 'DS'")
+	simulationTarget :=
+		flag.String("simulationTarget", config.Params.SimulationTarget, "The simulation target, CT: Confirmation Time, DS: Double Spending")
 	simulationMode :=
 		flag.String("simulationMode", config.Params.SimulationMode, "Mode for the DS simulations one of: 'Accidental' - accidental double spends sent by max, min or random weight node from Zipf distrib, 'Adversary' - need to use adversary groups (parameters starting with 'Adversary...')")
 	accidentalMana :=
 		flag.String("accidentalMana", "", "Defines node which will be used: min, max or random")
 	adversarySpeedup :=
 		flag.String("adversarySpeedup", "", "Adversary issuing speed relative to their mana, e.g. '10 10' means that nodes in each group will issue 10 times messages than would be allowed by their mana. SimulationTarget must be 'DS'")
+	adversaryBehaviors :=
+		flag.String("adversaryBehaviors", "", "Adversary behaviors of each group joined by '+', e.g. 'ShiftingOpinion+SelectiveGossip NoGossip'. Groups without behaviors use the behaviors of their adversaryType")
+	adversaryGossipFraction :=
+		flag.Float64("adversaryGossipFraction", config.Params.AdversaryGossipFraction, "The fraction of its neighbors an adversary node with the 'SelectiveGossip' behavior gossips to")
+	parasiteRootAge :=
+		flag.Duration("parasiteRootAge", config.Params.ParasiteRootAge, "The age of the message the side-tangle of the 'ParasiteChain' behavior starts from")
+	parasiteReleaseDelay :=
+		flag.Duration("parasiteReleaseDelay", config.Params.ParasiteReleaseDelay, "The time after the double spend after which the 'ParasiteChain' behavior releases the withheld side-tangle")
+	balancingOmniscient :=
+		flag.Bool("balancingOmniscient", config.Params.BalancingOmniscient, "Whether the 'Balancing' behavior reads the opinions of its neighbors from their nodes instead of estimating them from their last votes")
+	eclipseVictims :=
+		flag.String("eclipseVictims", "", "The IDs of the honest nodes whose neighbors are replaced by the adversary nodes with the 'Eclipse' behavior, e.g. '3 7'")
+	eclipseMode :=
+		flag.String("eclipseMode", config.Params.EclipseMode, "How the 'Eclipse' behavior treats the messages the victims see and forward, one of: 'Delay', 'Reorder', 'Drop'")
+	eclipseDelay :=
+		flag.Duration("eclipseDelay", config.Params.EclipseDelay, "The delay the 'Eclipse' behavior adds to the messages of the victims, the maximum random delay in the 'Reorder' mode")
+	eclipseDropProbability :=
+		flag.Float64("eclipseDropProbability", config.Params.EclipseDropProbability, "The probability that the 'Eclipse' behavior drops a message of the victims in the 'Drop' mode")
+	eclipseAnswerRequests :=
+		flag.Bool("eclipseAnswerRequests", config.Params.EclipseAnswerRequests, "Whether the 'Eclipse' behavior answers the requests of the victims for missing messages")
+	sybilConnections :=
+		flag.Int("sybilConnections", config.Params.SybilConnections, "The number of additional honest nodes every node with the 'Sybil' behavior is connected to, taking their neighbor slots")
+	censoredIssuers :=
+		flag.String("censoredIssuers", "", "The IDs of the issuers whose messages are censored by the 'CensorTips' and 'CensorScheduler' behaviors, e.g. '3 7'")
+	spamMode :=
+		flag.String("spamMode", config.Params.SpamMode, "When the 'Spam' behavior spams, one of: 'Constant', 'Bursty', 'Slot'")
+	spamRate :=
+		flag.Float64("spamRate", config.Params.SpamRate, "The rate of the spam of every node with the 'Spam' behavior in messages per second, on top of its issuing rate")
+	spamBurstLength :=
+		flag.Duration("spamBurstLength", config.Params.SpamBurstLength, "The length of the bursts of the 'Bursty' spamMode")
+	spamBurstPeriod :=
+		flag.Duration("spamBurstPeriod", config.Params.SpamBurstPeriod, "The period of the bursts of the 'Bursty' spamMode")
+	spamSlots :=
+		flag.String("spamSlots", "", "The indices of the slots spammed in the 'Slot' spamMode, e.g. '5 6 7'")
+	spamBurn :=
+		flag.String("spamBurn", config.Params.SpamBurn, "The mana the spam burns, one of: 'Policy', 'Minimal', 'Zero'")
+	spamParents :=
+		flag.String("spamParents", config.Params.SpamParents, "The parents of the spam, one of: 'Tips', 'Unsolid', 'Unready'")
+	timestampShiftMin :=
+		flag.Duration("timestampShiftMin", config.Params.TimestampShiftMin, "The minimum shift of the issuance time of the messages of the 'Timestamps' behavior, negative shifts back-date")
+	timestampShiftMax :=
+		flag.Duration("timestampShiftMax", config.Params.TimestampShiftMax, "The maximum shift of the issuance time of the messages of the 'Timestamps' behavior, positive shifts future-date")
+	equivocationMode :=
+		flag.String("equivocationMode", config.Params.EquivocationMode, "How the validation blocks of the 'Equivocation' behavior conflict, one of: 'Parents', 'Colors'")
+	blowballMana :=
+		flag.Int("blowballMana", config.Params.BlowballMana, "The mana of all blowball nodes together in % of total mana")
+	blowballSize :=
+		flag.Int("blowballSize", config.Params.BlowballSize, "The number of messages of a blowball")
+	blowballDelay :=
+		flag.Int("blowballDelay", config.Params.BlowballDelay, "The delay in seconds between the consecutive blowballs of a node")
+	blowballMaxSent :=
+		flag.Int("blowballMaxSent", config.Params.BlowballMaxSent, "The maximum number of blowballs sent by a node, 0 for no limit")
+	blowballNodeID :=
+		flag.Int("blowballNodeID", config.Params.BlowballNodeID, "The node ID of the first blowball node in the 'Blowball' simulationMode")
+	blowballNodeCount :=
+		flag.Int("blowballNodeCount", config.Params.BlowballNodeCount, "The number of blowball nodes in the 'Blowball' simulationMode")
+	blowballTarget :=
+		flag.String("blowballTarget", config.Params.BlowballTarget, "The message approved by the blowballs, one of: 'Oldest', 'Age'")
+	blowballTargetAge :=
+		flag.Duration("blowballTargetAge", config.Params.BlowballTargetAge, "The age of the message approved by the blowballs with the 'Age' blowballTarget")
+	blowballRules :=
+		flag.String("blowballRules", config.Params.BlowballRules, "Whether the blowballs follow the mana and scheduler rules, one of: 'Respect', 'Violate'")
 	adversaryPeeringAll :=
 		flag.Bool("adversaryPeeringAll", config.Params.AdversaryPeeringAll, "Flag indicating whether adversary nodes should be able to gossip messages to all nodes in the network directly, or should follow the peering algorithm.")
 	burnPolicies :=
-		flag.String("burnPolicies", "", "Space seperated list of policies employed by nodes, e.g., '0 1' . Options include: 0 = noburn, 1 = anxious, 2 = greedy, 3 = random_greedy")
+		flag.String("burnPolicies", "", "Space seperated list of policies employed by nodes, e.g., '0 1' . Options include: 0 = noburn, 1 = anxious, 2 = greedy1, 3 = greedy10, 4 = fee estimation, 5 = deadline, 6 = random greedy, 7 = budget")
 	scriptStartTime :=
 		flag.String("scriptStartTime", config.Params.ScriptStartTimeStr, "Time the external script started, to be used for results directory.")
 
@@ -134,10 +322,27 @@ func ParseFlags() {
 
 	// Update the configuration parameters
 	config.Params.NodesCount = *nodesCountPtr
+	config.Params.ValidatorCount = *validatorCountPtr
+	config.Params.ValidatorBPS = *validatorBPSPtr
+	config.Params.AdaptiveValidatorIssuance = *adaptiveValidatorIssuancePtr
 	config.Params.NodesTotalWeight = *nodesTotalWeightPtr
 	config.Params.ZipfParameter = *zipfParameterPtr
 	config.Params.ConfirmationThreshold = *confirmationThresholdPtr
 	config.Params.ConfirmationThresholdAbsolute = *confirmationThresholdAbsolutePtr
+	config.Params.ApprovalWeightEngine = *approvalWeightEnginePtr
+	if config.Params.ApprovalWeightEngine == "Indexed" {
+		log.Warnf("The Indexed approval weight engine stops at decided messages, their witness weight and exported weight no longer grow")
+	}
+	config.Params.AcceptanceGadget = *acceptanceGadgetPtr
+	config.Params.NonValidatorAcceptanceGadget = *nonValidatorAcceptanceGadgetPtr
+	config.Params.ConfirmationDepth = *confirmationDepthPtr
+	config.Params.ValidatorConfirmations = *validatorConfirmationsPtr
+	config.Params.AcceptanceThreshold = *acceptanceThresholdPtr
+	config.Params.OrphanageRule = *orphanageRulePtr
+	config.Params.TimestampMaxDrift = *timestampMaxDriftPtr
+	config.Params.TimestampParentOrder = *timestampParentOrderPtr
+	config.Params.TimestampMaxParentAge = *timestampMaxParentAgePtr
+	config.Params.EquivocationPolicy = *equivocationPolicyPtr
 	config.Params.ParentsCount = *parentsCountPtr
 	config.Params.WeakTipsRatio = *weakTipsRatioPtr
 	config.Params.TSA = *tsaPtr
@@ -155,6 +360,7 @@ func ParseFlags() {
 	config.Params.IMIF = *imif
 	config.Params.RandomnessWS = *randomnessWS
 	config.Params.NeighbourCountWS = *neighbourCountWS
+	config.Params.SimulationTarget = *simulationTarget
 	config.Params.SimulationMode = *simulationMode
 	config.Params.SchedulingRate = *schedulingRate
 	parseMonitoredAWPeers(*monitoredAWPeers)
@@ -162,13 +368,90 @@ func ParseFlags() {
 	parseCongestionPeriods(*congestionPeriods)
 	config.Params.ScriptStartTimeStr = *scriptStartTime
 	parseAccidentalConfig(accidentalMana)
-	parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, adversaryNodeCounts, adversaryInitColors, adversaryPeeringAll, adversarySpeedup)
+	parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, adversaryNodeCounts, adversaryInitColors, adversaryPeeringAll, adversarySpeedup, adversaryBehaviors)
+	config.Params.AdversaryGossipFraction = *adversaryGossipFraction
+	config.Params.ParasiteRootAge = *parasiteRootAge
+	config.Params.ParasiteReleaseDelay = *parasiteReleaseDelay
+	config.Params.BalancingOmniscient = *balancingOmniscient
+	parseEclipseVictims(*eclipseVictims)
+	config.Params.EclipseMode = *eclipseMode
+	config.Params.EclipseDelay = *eclipseDelay
+	config.Params.EclipseDropProbability = *eclipseDropProbability
+	config.Params.EclipseAnswerRequests = *eclipseAnswerRequests
+	config.Params.SybilConnections = *sybilConnections
+	parseCensoredIssuers(*censoredIssuers)
+	config.Params.SpamMode = *spamMode
+	config.Params.SpamRate = *spamRate
+	config.Params.SpamBurstLength = *spamBurstLength
+	config.Params.SpamBurstPeriod = *spamBurstPeriod
+	parseSpamSlots(*spamSlots)
+	config.Params.SpamBurn = *spamBurn
+	config.Params.SpamParents = *spamParents
+	config.Params.TimestampShiftMin = *timestampShiftMin
+	config.Params.TimestampShiftMax = *timestampShiftMax
+	config.Params.EquivocationMode = *equivocationMode
+	config.Params.BlowballMana = *blowballMana
+	config.Params.BlowballSize = *blowballSize
+	config.Params.BlowballDelay = *blowballDelay
+	config.Params.BlowballMaxSent = *blowballMaxSent
+	config.Params.BlowballNodeID = *blowballNodeID
+	config.Params.BlowballNodeCount = *blowballNodeCount
+	config.Params.BlowballTarget = *blowballTarget
+	config.Params.BlowballTargetAge = *blowballTargetAge
+	config.Params.BlowballRules = *blowballRules
 
 	config.Params.MonitoredWitnessWeightPeer = *monitoredWitnessWeightPeerPtr
 	config.Params.MonitoredWitnessWeightMessageID = *monitoredWitnessWeightMessageIDPtr
 	config.Params.SimulationDuration = *simulationDurationPtr
+	config.Params.PruningHorizon = *pruningHorizonPtr
+	config.Params.StreamGlobalMetrics = *streamGlobalMetricsPtr
+	config.Params.SharedMessageStore = *sharedMessageStorePtr
+	if *exportFormatsPtr != "" {
+		config.Params.ExportFormats = parseStr(*exportFormatsPtr)
+	}
+	config.Params.ExportNodeID = *exportNodeIDPtr
+	if config.Params.ExportNodeID < 0 || config.Params.ExportNodeID >= config.Params.NodesCount {
+		log.Warnf("ExportNodeID %d is not a node of the network, exporting node 0 instead", config.Params.ExportNodeID)
+		config.Params.ExportNodeID = 0
+	}
+	config.Params.ExportStartSlot = *exportStartSlotPtr
+	config.Params.ExportEndSlot = *exportEndSlotPtr
+	config.Params.ExportStartTime = *exportStartTimePtr
+	config.Params.ExportEndTime = *exportEndTimePtr
 	config.Params.SchedulerType = *schedulerTypePtr
+	config.Params.ManaManagerType = *manaManagerTypePtr
+	if config.Params.ManaManagerType == "" {
+		config.Params.ManaManagerType = config.Params.SchedulerType
+	}
+	config.Params.RateSetterType = *rateSetterTypePtr
+	if config.Params.RateSetterType == "" {
+		config.Params.RateSetterType = config.Params.SchedulerType
+	}
+	config.Params.AIMDIncrease = *aimdIncreasePtr
+	config.Params.AIMDDecrease = *aimdDecreasePtr
+	config.Params.AIMDMinRate = *aimdMinRatePtr
+	config.Params.AIMDQueueThreshold = *aimdQueueThresholdPtr
+	config.Params.AIMDHoldTime = *aimdHoldTimePtr
+	config.Params.TokenBucketSize = *tokenBucketSizePtr
 	config.Params.MaxDeficit = *maxDeficitPtr
+	config.Params.WorkDistribution = *workDistributionPtr
+	config.Params.MinWork = *minWorkPtr
+	if config.Params.MinWork < 1 {
+		config.Params.MinWork = 1
+	}
+	config.Params.MaxWork = *maxWorkPtr
+	if config.Params.MaxWork < config.Params.MinWork {
+		config.Params.MaxWork = config.Params.MinWork
+	}
+	config.Params.ValidationWork = *validationWorkPtr
+	if config.Params.ValidationWork < 1 {
+		config.Params.ValidationWork = 1
+	}
+	config.Params.ColoredWork = *coloredWorkPtr
+	config.Params.MaxBuffer = *maxBufferPtr
+	config.Params.DropPolicy = *dropPolicyPtr
+	config.Params.REDThreshold = *redThresholdPtr
+	config.Params.REDMaxProbability = *redMaxProbabilityPtr
 	config.Params.SlotTime = *slotTimePtr
 	config.Params.MinCommittableAge = *minCommittableAgePtr
 	config.Params.RMCTime = *rmcTimePtr
@@ -183,10 +466,52 @@ func ParseFlags() {
 	config.Params.RMCincrease = *rmcIncreasePtr
 	config.Params.RMCdecrease = *rmcDecreasePtr
 	config.Params.RMCPeriodUpdate = *rmcPeriodUpdatePtr
+	config.Params.RMCController = *rmcControllerPtr
+	config.Params.RMCTargetTraffic = *rmcTargetTrafficPtr
+	// the PID and EIP1559 controllers divide by the target traffic, so it has to be positive
+	if config.Params.RMCTargetTraffic <= 0 && config.Params.LowerRMCThreshold+config.Params.UpperRMCThreshold <= 0 {
+		log.Warnf("RMCTargetTraffic and the RMC thresholds are not positive, targeting 1 accepted message per slot instead")
+		config.Params.RMCTargetTraffic = 1
+	}
+	config.Params.RMCKp = *rmcKpPtr
+	config.Params.RMCKi = *rmcKiPtr
+	config.Params.RMCKd = *rmcKdPtr
+	config.Params.RMCAdjustmentQuotient = *rmcAdjustmentQuotientPtr
+	if config.Params.RMCAdjustmentQuotient < 1 {
+		config.Params.RMCAdjustmentQuotient = 1
+	}
+	config.Params.ManaGenerationRate = *manaGenerationRatePtr
+	config.Params.ManaDecay = *manaDecayPtr
+	config.Params.ManaCap = *manaCapPtr
+	config.Params.ManaTransferProbability = *manaTransferProbabilityPtr
+	config.Params.ManaTransferFraction = *manaTransferFractionPtr
+	config.Params.ManaAllocationFile = *manaAllocationFilePtr
+	config.Params.ManaLedgerMode = *manaLedgerModePtr
+	config.Params.BurnHistorySize = *burnHistorySizePtr
+	config.Params.BurnFeePercentile = *burnFeePercentilePtr
+	config.Params.BurnDeadline = *burnDeadlinePtr
+	config.Params.BurnRandomGreedyMax = *burnRandomGreedyMaxPtr
+	config.Params.BurnBudget = *burnBudgetPtr
+	config.Params.BurnBudgetWindow = *burnBudgetWindowPtr
+	config.Params.ValidationScheduling = *validationSchedulingPtr
+	config.Params.ValidationLaneRate = *validationLaneRatePtr
+	config.Params.ValidationLaneBurst = *validationLaneBurstPtr
 
 	log.Info("Current configuration:")
 	log.Info("Simulation Duration: ", config.Params.SimulationDuration)
+	log.Info("PruningHorizon: ", config.Params.PruningHorizon)
+	log.Info("StreamGlobalMetrics: ", config.Params.StreamGlobalMetrics)
+	log.Info("SharedMessageStore: ", config.Params.SharedMessageStore)
+	log.Info("ExportFormats: ", config.Params.ExportFormats)
+	log.Info("ExportNodeID: ", config.Params.ExportNodeID)
+	log.Info("ExportStartSlot: ", config.Params.ExportStartSlot)
+	log.Info("ExportEndSlot: ", config.Params.ExportEndSlot)
+	log.Info("ExportStartTime: ", config.Params.ExportStartTime)
+	log.Info("ExportEndTime: ", config.Params.ExportEndTime)
 	log.Info("NodesCount: ", config.Params.NodesCount)
+	log.Info("ValidatorCount: ", config.Params.ValidatorCount)
+	log.Info("ValidatorBPS: ", config.Params.ValidatorBPS)
+	log.Info("AdaptiveValidatorIssuance: ", config.Params.AdaptiveValidatorIssuance)
 	log.Info("NodesTotalWeight: ", config.Params.NodesTotalWeight)
 	log.Info("ZipfParameter: ", config.Params.ZipfParameter)
 	log.Info("MonitoredAWPeers:", config.Params.MonitoredAWPeers)
@@ -194,10 +519,29 @@ func ParseFlags() {
 	log.Info("MonitoredWitnessWeightMessageID: ", config.Params.MonitoredWitnessWeightMessageID)
 	log.Info("ConfirmationThreshold: ", config.Params.ConfirmationThreshold)
 	log.Info("ConfirmationThresholdAbsolute: ", config.Params.ConfirmationThresholdAbsolute)
+	log.Info("ApprovalWeightEngine: ", config.Params.ApprovalWeightEngine)
+	log.Info("AcceptanceGadget: ", config.Params.AcceptanceGadget)
+	log.Info("NonValidatorAcceptanceGadget: ", config.Params.NonValidatorAcceptanceGadget)
+	log.Info("ConfirmationDepth: ", config.Params.ConfirmationDepth)
+	log.Info("ValidatorConfirmations: ", config.Params.ValidatorConfirmations)
+	log.Info("AcceptanceThreshold: ", config.Params.AcceptanceThreshold)
+	log.Info("OrphanageRule: ", config.Params.OrphanageRule)
+	log.Info("TimestampMaxDrift: ", config.Params.TimestampMaxDrift)
+	log.Info("TimestampParentOrder: ", config.Params.TimestampParentOrder)
+	log.Info("TimestampMaxParentAge: ", config.Params.TimestampMaxParentAge)
+	log.Info("EquivocationPolicy: ", config.Params.EquivocationPolicy)
 	log.Info("ParentsCount: ", config.Params.ParentsCount)
 	log.Info("WeakTipsRatio: ", config.Params.WeakTipsRatio)
 	log.Info("TSA: ", config.Params.TSA)
 	log.Info("SchedulerType: ", config.Params.SchedulerType)
+	log.Info("ManaManagerType: ", config.Params.ManaManagerType)
+	log.Info("RateSetterType: ", config.Params.RateSetterType)
+	log.Info("AIMDIncrease: ", config.Params.AIMDIncrease)
+	log.Info("AIMDDecrease: ", config.Params.AIMDDecrease)
+	log.Info("AIMDMinRate: ", config.Params.AIMDMinRate)
+	log.Info("AIMDQueueThreshold: ", config.Params.AIMDQueueThreshold)
+	log.Info("AIMDHoldTime: ", config.Params.AIMDHoldTime)
+	log.Info("TokenBucketSize: ", config.Params.TokenBucketSize)
 	log.Info("SchedulingRate: ", config.Params.SchedulingRate)
 	log.Info("IssuingRate: ", config.Params.IssuingRate)
 	log.Info("Congestion periods:", config.Params.CongestionPeriods)
@@ -208,6 +552,14 @@ func ParseFlags() {
 	log.Info("Initial Mana:", config.Params.InitialMana)
 	log.Info("Max Buffer size:", config.Params.MaxBuffer)
 	log.Info("Max Deficit:", config.Params.MaxDeficit)
+	log.Info("DropPolicy: ", config.Params.DropPolicy)
+	log.Info("REDThreshold: ", config.Params.REDThreshold)
+	log.Info("REDMaxProbability: ", config.Params.REDMaxProbability)
+	log.Info("WorkDistribution: ", config.Params.WorkDistribution)
+	log.Info("MinWork: ", config.Params.MinWork)
+	log.Info("MaxWork: ", config.Params.MaxWork)
+	log.Info("ValidationWork: ", config.Params.ValidationWork)
+	log.Info("ColoredWork: ", config.Params.ColoredWork)
 	log.Info("Slot time duration:", config.Params.SlotTime)
 	log.Info("MinCommittableAge:", config.Params.MinCommittableAge)
 	log.Info("RMCTime: ", config.Params.RMCTime)
@@ -221,6 +573,28 @@ func ParseFlags() {
 	log.Info("RMCincrease: ", config.Params.RMCincrease)
 	log.Info("RMCdecrease: ", config.Params.RMCdecrease)
 	log.Info("RMCPeriodUpdate: ", config.Params.RMCPeriodUpdate)
+	log.Info("RMCController: ", config.Params.RMCController)
+	log.Info("RMCTargetTraffic: ", config.Params.RMCTargetTraffic)
+	log.Info("RMCKp: ", config.Params.RMCKp)
+	log.Info("RMCKi: ", config.Params.RMCKi)
+	log.Info("RMCKd: ", config.Params.RMCKd)
+	log.Info("RMCAdjustmentQuotient: ", config.Params.RMCAdjustmentQuotient)
+	log.Info("ManaGenerationRate: ", config.Params.ManaGenerationRate)
+	log.Info("ManaDecay: ", config.Params.ManaDecay)
+	log.Info("ManaCap: ", config.Params.ManaCap)
+	log.Info("ManaTransferProbability: ", config.Params.ManaTransferProbability)
+	log.Info("ManaTransferFraction: ", config.Params.ManaTransferFraction)
+	log.Info("ManaAllocationFile: ", config.Params.ManaAllocationFile)
+	log.Info("ManaLedgerMode: ", config.Params.ManaLedgerMode)
+	log.Info("BurnHistorySize: ", config.Params.BurnHistorySize)
+	log.Info("BurnFeePercentile: ", config.Params.BurnFeePercentile)
+	log.Info("BurnDeadline: ", config.Params.BurnDeadline)
+	log.Info("BurnRandomGreedyMax: ", config.Params.BurnRandomGreedyMax)
+	log.Info("BurnBudget: ", config.Params.BurnBudget)
+	log.Info("BurnBudgetWindow: ", config.Params.BurnBudgetWindow)
+	log.Info("ValidationScheduling: ", config.Params.ValidationScheduling)
+	log.Info("ValidationLaneRate: ", config.Params.ValidationLaneRate)
+	log.Info("ValidationLaneBurst: ", config.Params.ValidationLaneBurst)
 	log.Info("DoubleSpendDelay: ", config.Params.DoubleSpendDelay)
 	log.Info("PacketLoss: ", config.Params.PacketLoss)
 	log.Info("MinDelay: ", config.Params.MinDelay)
@@ -231,6 +605,7 @@ func ParseFlags() {
 	log.Info("IMIF: ", config.Params.IMIF)
 	log.Info("WattsStrogatzRandomness: ", config.Params.RandomnessWS)
 	log.Info("WattsStrogatzNeighborCount: ", config.Params.NeighbourCountWS)
+	log.Info("SimulationTarget: ", config.Params.SimulationTarget)
 	log.Info("SimulationMode: ", config.Params.SimulationMode)
 	log.Info("AdversaryTypes: ", config.Params.AdversaryTypes)
 	log.Info("AdversaryInitColors: ", config.Params.AdversaryInitColors)
@@ -240,6 +615,37 @@ func ParseFlags() {
 	log.Info("AccidentalMana: ", config.Params.AccidentalMana)
 	log.Info("AdversaryPeeringAll: ", config.Params.AdversaryPeeringAll)
 	log.Info("AdversarySpeedup: ", config.Params.AdversarySpeedup)
+	log.Info("AdversaryBehaviors: ", config.Params.AdversaryBehaviors)
+	log.Info("AdversaryGossipFraction: ", config.Params.AdversaryGossipFraction)
+	log.Info("ParasiteRootAge: ", config.Params.ParasiteRootAge)
+	log.Info("ParasiteReleaseDelay: ", config.Params.ParasiteReleaseDelay)
+	log.Info("BalancingOmniscient: ", config.Params.BalancingOmniscient)
+	log.Info("EclipseVictims: ", config.Params.EclipseVictims)
+	log.Info("EclipseMode: ", config.Params.EclipseMode)
+	log.Info("EclipseDelay: ", config.Params.EclipseDelay)
+	log.Info("EclipseDropProbability: ", config.Params.EclipseDropProbability)
+	log.Info("EclipseAnswerRequests: ", config.Params.EclipseAnswerRequests)
+	log.Info("SybilConnections: ", config.Params.SybilConnections)
+	log.Info("CensoredIssuers: ", config.Params.CensoredIssuers)
+	log.Info("SpamMode: ", config.Params.SpamMode)
+	log.Info("SpamRate: ", config.Params.SpamRate)
+	log.Info("SpamBurstLength: ", config.Params.SpamBurstLength)
+	log.Info("SpamBurstPeriod: ", config.Params.SpamBurstPeriod)
+	log.Info("SpamSlots: ", config.Params.SpamSlots)
+	log.Info("SpamBurn: ", config.Params.SpamBurn)
+	log.Info("SpamParents: ", config.Params.SpamParents)
+	log.Info("TimestampShiftMin: ", config.Params.TimestampShiftMin)
+	log.Info("TimestampShiftMax: ", config.Params.TimestampShiftMax)
+	log.Info("EquivocationMode: ", config.Params.EquivocationMode)
+	log.Info("BlowballMana: ", config.Params.BlowballMana)
+	log.Info("BlowballSize: ", config.Params.BlowballSize)
+	log.Info("BlowballDelay: ", config.Params.BlowballDelay)
+	log.Info("BlowballMaxSent: ", config.Params.BlowballMaxSent)
+	log.Info("BlowballNodeID: ", config.Params.BlowballNodeID)
+	log.Info("BlowballNodeCount: ", config.Params.BlowballNodeCount)
+	log.Info("BlowballTarget: ", config.Params.BlowballTarget)
+	log.Info("BlowballTargetAge: ", config.Params.BlowballTargetAge)
+	log.Info("BlowballRules: ", config.Params.BlowballRules)
 }
 
 func parseMonitoredAWPeers(peers string) {
@@ -250,6 +656,27 @@ func parseMonitoredAWPeers(peers string) {
 	config.Params.MonitoredAWPeers = peersInt
 }
 
+func parseEclipseVictims(victims string) {
+	if victims == "" {
+		return
+	}
+	config.Params.EclipseVictims = parseStrToInt(victims)
+}
+
+func parseCensoredIssuers(issuers string) {
+	if issuers == "" {
+		return
+	}
+	config.Params.CensoredIssuers = parseStrToInt(issuers)
+}
+
+func parseSpamSlots(slots string) {
+	if slots == "" {
+		return
+	}
+	config.Params.SpamSlots = parseStrToInt(slots)
+}
+
 func parseCongestionPeriods(periods string) {
 	if periods == "" {
 		return
@@ -260,6 +687,10 @@ func parseCongestionPeriods(periods string) {
 
 func parseBurnPolicies(burnPolicies string) {
 	if burnPolicies == "" {
+		// the default policies are generated for the default number of nodes
+		if len(config.Params.BurnPolicies) != config.Params.NodesCount {
+			config.Params.BurnPolicies = config.RandomArrayFromValues(0, []int{0, 1}, config.Params.NodesCount)
+		}
 		return
 	}
 	policiesInt := parseStrToInt(burnPolicies)
@@ -270,7 +701,7 @@ func parseBurnPolicies(burnPolicies string) {
 	}
 }
 
-func parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, adversaryNodeCounts, adversaryInitColors *string, adversaryPeeringAll *bool, adversarySpeedup *string) {
+func parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, adversaryNodeCounts, adversaryInitColors *string, adversaryPeeringAll *bool, adversarySpeedup, adversaryBehaviors *string) {
 	if config.Params.SimulationMode != "Adversary" {
 		config.Params.AdversaryTypes = []int{}
 		config.Params.AdversaryNodeCounts = []int{}
@@ -278,6 +709,7 @@ func parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, advers
 		config.Params.AdversaryDelays = []int{}
 		config.Params.AdversaryInitColors = []string{}
 		config.Params.AdversarySpeedup = []float64{}
+		config.Params.AdversaryBehaviors = []string{}
 
 		return
 	}
@@ -302,6 +734,13 @@ func parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, advers
 	if *adversarySpeedup != "" {
 		config.Params.AdversarySpeedup = parseStrToFloat64(*adversarySpeedup)
 	}
+	if *adversaryBehaviors != "" {
+		config.Params.AdversaryBehaviors = parseStr(*adversaryBehaviors)
+		// the behaviors define the groups, the adversary types are only needed for the groups without behaviors
+		if *adversaryTypes == "" {
+			config.Params.AdversaryTypes = make([]int, len(config.Params.AdversaryBehaviors))
+		}
+	}
 	// no adversary if colors are not provided
 	if len(config.Params.AdversaryInitColors) != len(config.Params.AdversaryTypes) {
 		config.Params.AdversaryTypes = []int{}
@@ -320,6 +759,10 @@ func parseAdversaryConfig(adversaryDelays, adversaryTypes, adversaryMana, advers
 		log.Warnf("The AdversaryNodeCounts count is not equal to the AdversaryTypes count!")
 		config.Params.AdversaryNodeCounts = []int{}
 	}
+	if len(config.Params.AdversaryBehaviors) != 0 && len(config.Params.AdversaryBehaviors) != len(config.Params.AdversaryTypes) {
+		log.Warnf("The AdversaryBehaviors count is not equal to the AdversaryTypes count!")
+		config.Params.AdversaryBehaviors = []string{}
+	}
 }
 
 func parseAccidentalConfig(accidentalMana *string) {
@@ -1,6 +1,7 @@
 package singlenodeattacks
 
 import (
+	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/types"
@@ -8,52 +9,152 @@ import (
 	"github.com/iotaledger/multivers-simulation/multiverse"
 )
 
+// blowballNodes are all nodes with the 'Blowball' behavior.
+var blowballNodes []*BlowballNode
+
+// BlowballNodes returns all nodes with the 'Blowball' behavior.
+func BlowballNodes() []*BlowballNode {
+	return blowballNodes
+}
+
 type BlowballNode struct {
 	*multiverse.Node
 
-	nearTSCSet *multiverse.TipSet
+	blowballs    []*IssuedBlowball
+	lastBlowball time.Time
+	// unscheduled are the issued messages that are gossiped right away and skip the own scheduler
+	unscheduled multiverse.MessageIDs
+	mutex       sync.RWMutex
+}
+
+// IssuedBlowball is a blowball issued by a BlowballNode.
+type IssuedBlowball struct {
+	// Center is the message approved by all messages of the blowball
+	Center multiverse.MessageID
+	// Target is the unconfirmed message approved by the center
+	Target multiverse.MessageID
+	// TargetAge is the age of the target when the blowball was issued
+	TargetAge    time.Duration
+	Messages     multiverse.MessageIDs
+	IssuanceTime time.Time
+}
+
+// Blowball replaces a data message of the node by a blowball every BlowballDelay, until BlowballMaxSent blowballs are
+// sent. A blowball is a message that approves an unconfirmed message and many messages that approve it. The other
+// data messages are issued as usual.
+func Blowball(node *multiverse.Node) {
+	blowballNode := &BlowballNode{
+		Node:         node,
+		lastBlowball: time.Now(),
+		unscheduled:  multiverse.NewMessageIDs(),
+	}
+	blowballNodes = append(blowballNodes, blowballNode)
+	node.Tangle().Hooks.IssuanceHandlers = append(node.Tangle().Hooks.IssuanceHandlers, func(payload multiverse.Color) bool {
+		if !blowballNode.blowballDue() {
+			return false
+		}
+		blowballNode.IssueBlowball(payload)
+		return true
+	})
+	node.Tangle().Hooks.EnqueueFilters = append(node.Tangle().Hooks.EnqueueFilters, func(message *multiverse.Message) bool {
+		return !blowballNode.skipsScheduler(message.ID)
+	})
+}
+
+// Blowballs returns the blowballs issued by the node.
+func (n *BlowballNode) Blowballs() []*IssuedBlowball {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return append([]*IssuedBlowball{}, n.blowballs...)
 }
 
-func NewBlowballNode() interface{} {
-	node := multiverse.NewNode().(*multiverse.Node)
-	blowBallNode := &BlowballNode{
-		Node:       node,
-		nearTSCSet: multiverse.NewTipSet(nil),
+func (n *BlowballNode) blowballDue() bool {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	if config.Params.BlowballMaxSent > 0 && len(n.blowballs) >= config.Params.BlowballMaxSent {
+		return false
 	}
-	return blowBallNode
+	return time.Since(n.lastBlowball) >= time.Duration(config.Params.SlowdownFactor*config.Params.BlowballDelay)*time.Second
 }
 
-func (n *BlowballNode) IssuePayload(payload multiverse.Color) {
+// IssueBlowball issues a message that approves the target unconfirmed message and a blowball around it. The target is
+// the oldest unconfirmed message or, with the 'Age' BlowballTarget, the one closest to BlowballTargetAge.
+func (n *BlowballNode) IssueBlowball(payload multiverse.Color) {
+	n.mutex.Lock()
+	n.lastBlowball = time.Now()
+	n.mutex.Unlock()
+
 	// create a blow ball
 	tm := n.Tangle().TipManager
 	tipSet := tm.TipSet(multiverse.UndefinedColor)
-	oldestMessageID := tm.WalkForOldestUnconfirmed(tipSet)
-	oldestMessage := n.CreateMessage(oldestMessageID, payload)
-	// gossip and process oldest message
-	n.Tangle().ProcessMessage(oldestMessage)
-	n.Peer().GossipNetworkMessage(oldestMessage)
+	var targetID multiverse.MessageID
+	switch config.Params.BlowballTarget {
+	case "Age":
+		targetID = tm.WalkForUnconfirmedOfAge(tipSet, config.Params.BlowballTargetAge*time.Duration(config.Params.SlowdownFactor))
+	default:
+		targetID = tm.WalkForOldestUnconfirmed(tipSet)
+	}
+	targetAge := time.Duration(0)
+	if target := n.Tangle().Storage.Message(targetID); targetID != multiverse.Genesis && target != nil {
+		targetAge = time.Since(target.IssuanceTime)
+	}
+
+	centerMessage, ok := n.CreateMessage(targetID, payload)
+	if !ok {
+		return
+	}
+	// gossip and process the center message
+	n.issueMessage(centerMessage)
 
 	// create and issue blowball
-	blowBall := n.CreateBlowBall(oldestMessage, payload)
+	blowBall := n.CreateBlowBall(centerMessage, payload)
 	for _, message := range blowBall {
-		n.Tangle().ProcessMessage(message)
-		n.Peer().GossipNetworkMessage(message)
+		n.issueMessage(message)
+	}
+
+	blowballMessages := multiverse.NewMessageIDs(centerMessage.ID)
+	for messageID := range blowBall {
+		blowballMessages.Add(messageID)
 	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.blowballs = append(n.blowballs, &IssuedBlowball{
+		Center:       centerMessage.ID,
+		Target:       targetID,
+		TargetAge:    targetAge,
+		Messages:     blowballMessages,
+		IssuanceTime: centerMessage.IssuanceTime,
+	})
 }
 
 func (n *BlowballNode) CreateBlowBall(centerMessage *multiverse.Message, payload multiverse.Color) map[multiverse.MessageID]*multiverse.Message {
 	blowBallMessages := make(map[multiverse.MessageID]*multiverse.Message)
 	for i := 0; i < config.Params.BlowballSize; i++ {
-		m := n.CreateMessage(centerMessage.ID, payload)
+		m, ok := n.CreateMessage(centerMessage.ID, payload)
+		if !ok {
+			break
+		}
 		blowBallMessages[m.ID] = m
 	}
 	return blowBallMessages
 }
 
-func (n *BlowballNode) CreateMessage(parent multiverse.MessageID, payload multiverse.Color) *multiverse.Message {
-	// create a new message
+// CreateMessage creates a message that only approves the given parent. With the 'Respect' BlowballRules the message
+// burns mana like an honest one and fails if the node cannot pay for it, otherwise it is free.
+func (n *BlowballNode) CreateMessage(parent multiverse.MessageID, payload multiverse.Color) (*multiverse.Message, bool) {
 	strongParents := multiverse.MessageIDs{parent: types.Void}
 	weakParents := multiverse.MessageIDs{}
+	if config.Params.BlowballRules == "Respect" {
+		m, ok := n.Tangle().MessageFactory.CreateMessage(false, payload)
+		if !ok {
+			return nil, false
+		}
+		m.StrongParents = strongParents
+		m.WeakParents = weakParents
+		return m, true
+	}
+
+	// create a new message
 	m := &multiverse.Message{
 		ID:             multiverse.NewMessageID(),
 		StrongParents:  strongParents,
@@ -62,10 +163,33 @@ func (n *BlowballNode) CreateMessage(parent multiverse.MessageID, payload multiv
 		Issuer:         n.Tangle().Peer.ID,
 		Payload:        payload,
 		IssuanceTime:   time.Now(),
+		Work:           n.Tangle().MessageFactory.Work(false, payload),
 	}
-	return m
+	return m, true
 }
 
-func (n *BlowballNode) AssignColor(color multiverse.Color) {
-	// no need to assign the color to the node for now
+// issueMessage processes the message, with the 'Respect' BlowballRules the own scheduler gossips it, otherwise it is
+// gossiped right away and skips the own scheduler, so it is not gossiped a second time.
+func (n *BlowballNode) issueMessage(message *multiverse.Message) {
+	if config.Params.BlowballRules == "Respect" {
+		n.Tangle().ProcessMessage(message)
+		return
+	}
+
+	n.mutex.Lock()
+	n.unscheduled.Add(message.ID)
+	n.mutex.Unlock()
+	n.Tangle().ProcessMessage(message)
+	n.Peer().GossipNetworkMessage(message)
+}
+
+// skipsScheduler returns true if the message was gossiped right away, it is forgotten once it is booked.
+func (n *BlowballNode) skipsScheduler(messageID multiverse.MessageID) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if _, unscheduled := n.unscheduled[messageID]; !unscheduled {
+		return false
+	}
+	delete(n.unscheduled, messageID)
+	return true
 }
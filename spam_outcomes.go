@@ -0,0 +1,164 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region spam /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	spamOutcomes        = make(map[network.PeerID]*spamOutcome)
+	spamDecidedMessages = make(map[multiverse.MessageID]bool)
+	spamDroppedMessages = make(map[multiverse.MessageID]bool)
+	spamOutcomeMutex    sync.Mutex
+)
+
+// spamOutcome tracks the messages of an issuer until they are first confirmed or dropped by any node.
+type spamOutcome struct {
+	issued              int64
+	confirmed           int64
+	confirmationTime    time.Duration
+	maxConfirmationTime time.Duration
+	dropped             int64
+}
+
+func (s *spamOutcome) add(other *spamOutcome) {
+	s.issued += other.issued
+	s.confirmed += other.confirmed
+	s.confirmationTime += other.confirmationTime
+	s.dropped += other.dropped
+	if other.maxConfirmationTime > s.maxConfirmationTime {
+		s.maxConfirmationTime = other.maxConfirmationTime
+	}
+}
+
+// monitorSpam records the confirmation latency and the drops of the messages of every node, if there are spammers.
+func monitorSpam(net *network.Network) {
+	if len(adversary.Spammers()) == 0 {
+		return
+	}
+
+	for _, peer := range net.Peers {
+		spamOutcomes[peer.ID] = &spamOutcome{}
+	}
+	for _, peer := range net.Peers {
+		peerID := peer.ID
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID multiverse.MessageID, message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+			if message.Issuer != peerID {
+				return
+			}
+			spamOutcomeMutex.Lock()
+			defer spamOutcomeMutex.Unlock()
+			spamOutcomes[peerID].issued++
+		}))
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			spamOutcomeMutex.Lock()
+			defer spamOutcomeMutex.Unlock()
+			if spamDecidedMessages[message.ID] {
+				return
+			}
+			spamDecidedMessages[message.ID] = true
+			issuerOutcome := spamOutcomes[message.Issuer]
+			confirmationTime := messageMetadata.ConfirmationTime().Sub(message.IssuanceTime)
+			issuerOutcome.confirmed++
+			issuerOutcome.confirmationTime += confirmationTime
+			if confirmationTime > issuerOutcome.maxConfirmationTime {
+				issuerOutcome.maxConfirmationTime = confirmationTime
+			}
+		}))
+		tangle.Scheduler.Events().MessageDropped.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
+			message := tangle.Storage.Message(messageID)
+			if message == nil {
+				return
+			}
+			spamOutcomeMutex.Lock()
+			defer spamOutcomeMutex.Unlock()
+			if spamDroppedMessages[messageID] {
+				return
+			}
+			spamDroppedMessages[messageID] = true
+			spamOutcomes[message.Issuer].dropped++
+		}))
+	}
+}
+
+// dumpSpam writes the confirmation latency and the drop rate of the messages of every node and of all honest nodes
+// together, so runs with different SchedulerTypes and spam settings can be compared.
+func dumpSpam(net *network.Network) {
+	spammers := adversary.Spammers()
+	if len(spammers) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Spammer",
+		"Scheduler Type",
+		"Spam Mode",
+		"Spam Burn",
+		"Spam Parents",
+		"Issued Messages",
+		"Spam Messages",
+		"Confirmed Messages",
+		"Mean Confirmation Latency (ns)",
+		"Max Confirmation Latency (ns)",
+		"Dropped Messages",
+		"Drop Rate",
+	}
+	rows := make(csvRows, 0)
+
+	spamMessages := make(map[network.PeerID]int)
+	for _, spammer := range spammers {
+		spamMessages[spammer.Node().Peer().ID] = spammer.Issued()
+	}
+	addOutcome := func(nodeID string, spammer bool, spam int, outcome *spamOutcome) {
+		meanConfirmationLatency, dropRate := int64(0), 0.0
+		if outcome.confirmed > 0 {
+			meanConfirmationLatency = outcome.confirmationTime.Nanoseconds() / outcome.confirmed
+		}
+		if outcome.issued > 0 {
+			dropRate = float64(outcome.dropped) / float64(outcome.issued)
+		}
+		record := []string{
+			nodeID,
+			strconv.FormatBool(spammer),
+			config.Params.SchedulerType,
+			config.Params.SpamMode,
+			config.Params.SpamBurn,
+			config.Params.SpamParents,
+			strconv.FormatInt(outcome.issued, 10),
+			strconv.Itoa(spam),
+			strconv.FormatInt(outcome.confirmed, 10),
+			strconv.FormatInt(meanConfirmationLatency, 10),
+			strconv.FormatInt(outcome.maxConfirmationTime.Nanoseconds(), 10),
+			strconv.FormatInt(outcome.dropped, 10),
+			strconv.FormatFloat(dropRate, 'f', 6, 64),
+		}
+		rows = append(rows, record)
+	}
+
+	spamOutcomeMutex.Lock()
+	defer spamOutcomeMutex.Unlock()
+	honestNodes := &spamOutcome{}
+	for _, peer := range net.Peers {
+		spam, spammer := spamMessages[peer.ID]
+		addOutcome(strconv.FormatInt(int64(peer.ID), 10), spammer, spam, spamOutcomes[peer.ID])
+		if !spammer && !network.IsAdversary(int(peer.ID)) {
+			honestNodes.add(spamOutcomes[peer.ID])
+		}
+	}
+	addOutcome("Honest", false, 0, honestNodes)
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "spam.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
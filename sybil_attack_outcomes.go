@@ -0,0 +1,144 @@
+package main
+
+import (
+	"math"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region sybil attack /////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	sybilIDs          = make(map[network.PeerID]bool)
+	sybilOutcomes     = map[bool]*sybilAttackOutcome{true: {}, false: {}}
+	sybilOutcomeMutex sync.Mutex
+)
+
+// sybilAttackOutcome counts the data messages of a set of issuers scheduled and dropped by the honest nodes.
+type sybilAttackOutcome struct {
+	scheduled int64
+	dropped   int64
+}
+
+// monitorSybilAttack counts the data messages of the Sybil identities and of the honest nodes scheduled and dropped by
+// every honest node, if there are Sybil identities.
+func monitorSybilAttack(net *network.Network) {
+	if len(adversary.Sybils()) == 0 {
+		return
+	}
+
+	for _, sybil := range adversary.Sybils() {
+		sybilIDs[sybil.Peer().ID] = true
+	}
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		// countMessage returns the outcome of the issuer of the data message, nil for the other adversary nodes
+		countMessage := func(messageID multiverse.MessageID, count func(outcome *sybilAttackOutcome)) {
+			message := tangle.Storage.Message(messageID)
+			if message == nil || message.Validation {
+				return
+			}
+			if !sybilIDs[message.Issuer] && network.IsAdversary(int(message.Issuer)) {
+				return
+			}
+			sybilOutcomeMutex.Lock()
+			defer sybilOutcomeMutex.Unlock()
+			count(sybilOutcomes[sybilIDs[message.Issuer]])
+		}
+		tangle.Scheduler.Events().MessageScheduled.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
+			countMessage(messageID, func(outcome *sybilAttackOutcome) { outcome.scheduled++ })
+		}))
+		tangle.Scheduler.Events().MessageDropped.Attach(events.NewClosure(func(messageID multiverse.MessageID) {
+			countMessage(messageID, func(outcome *sybilAttackOutcome) { outcome.dropped++ })
+		}))
+	}
+}
+
+// dumpSybilAttack writes a row for the Sybil identities and a row for the honest nodes with their share of the
+// weight, the bandwidth, the neighbor slots of the honest nodes and the data messages scheduled by the honest nodes.
+func dumpSybilAttack(net *network.Network) {
+	if len(adversary.Sybils()) == 0 {
+		return
+	}
+
+	header := []string{
+		"Set",
+		"Identities",
+		"Weight Share",
+		"Bandwidth Share",
+		"Honest Neighbor Slots",
+		"Honest Neighbor Slot Share",
+		"Honest Nodes Reached",
+		"Scheduled Messages",
+		"Throughput Share",
+		"Dropped Messages",
+	}
+	rows := make(csvRows, 0)
+
+	identities := map[bool]int{}
+	weights := map[bool]uint64{}
+	bandwidths := map[bool]float64{}
+	neighborSlots := map[bool]int{}
+	honestNodesReached := map[bool]int{}
+	totalNeighborSlots := 0
+	for _, peer := range net.Peers {
+		sybil := sybilIDs[peer.ID]
+		if !sybil && network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		identities[sybil]++
+		weights[sybil] += net.WeightDistribution.Weight(peer.ID)
+		bandwidths[sybil] += net.BandwidthDistribution.Bandwidth(peer.ID)
+		if sybil {
+			continue
+		}
+
+		reached := map[bool]bool{}
+		for neighborID := range peer.Neighbors {
+			totalNeighborSlots++
+			if sybilIDs[neighborID] || !network.IsAdversary(int(neighborID)) {
+				neighborSlots[sybilIDs[neighborID]]++
+				reached[sybilIDs[neighborID]] = true
+			}
+		}
+		for set := range reached {
+			honestNodesReached[set]++
+		}
+	}
+
+	sybilOutcomeMutex.Lock()
+	defer sybilOutcomeMutex.Unlock()
+	totalScheduled := sybilOutcomes[true].scheduled + sybilOutcomes[false].scheduled
+	for _, set := range []bool{true, false} {
+		name := "Honest"
+		if set {
+			name = "Sybil"
+		}
+		record := []string{
+			name,
+			strconv.Itoa(identities[set]),
+			strconv.FormatFloat(float64(weights[set])/math.Max(float64(net.WeightDistribution.TotalWeight()), 1), 'f', 6, 64),
+			strconv.FormatFloat(bandwidths[set]/math.Max(net.BandwidthDistribution.TotalBandwidth(), 1), 'f', 6, 64),
+			strconv.Itoa(neighborSlots[set]),
+			strconv.FormatFloat(float64(neighborSlots[set])/math.Max(float64(totalNeighborSlots), 1), 'f', 6, 64),
+			strconv.Itoa(honestNodesReached[set]),
+			strconv.FormatInt(sybilOutcomes[set].scheduled, 10),
+			strconv.FormatFloat(float64(sybilOutcomes[set].scheduled)/math.Max(float64(totalScheduled), 1), 'f', 6, 64),
+			strconv.FormatInt(sybilOutcomes[set].dropped, 10),
+		}
+		rows = append(rows, record)
+	}
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "sybilAttack.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"math"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/multivers-simulation/adversary"
+	"github.com/iotaledger/multivers-simulation/config"
+	"github.com/iotaledger/multivers-simulation/multiverse"
+	"github.com/iotaledger/multivers-simulation/network"
+)
+
+// region timestamp manipulation ///////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	timestampOutcomes         = make(map[network.PeerID]*timestampOutcome)
+	timestampDecidedMessages  = make(map[multiverse.MessageID]bool)
+	timestampRejectedMessages = make(map[multiverse.MessageID]bool)
+	timestampOutcomeMutex     sync.Mutex
+)
+
+// timestampOutcome tracks the messages of an issuer until they are first confirmed or orphaned by an honest node, and
+// how often honest nodes rejected them because of their timestamps.
+type timestampOutcome struct {
+	issued            int64
+	rejected          int64
+	rejectionsByRule  map[string]int64
+	confirmed         int64
+	orphaned          int64
+	acceptanceLatency time.Duration
+}
+
+func newTimestampOutcome() *timestampOutcome {
+	return &timestampOutcome{rejectionsByRule: make(map[string]int64)}
+}
+
+func (t *timestampOutcome) add(other *timestampOutcome) {
+	t.issued += other.issued
+	t.rejected += other.rejected
+	for rule, rejections := range other.rejectionsByRule {
+		t.rejectionsByRule[rule] += rejections
+	}
+	t.confirmed += other.confirmed
+	t.orphaned += other.orphaned
+	t.acceptanceLatency += other.acceptanceLatency
+}
+
+// monitorTimestamps records the rejections, confirmations and orphans of the messages of every node at the honest
+// nodes, if there are timestamp manipulators.
+func monitorTimestamps(net *network.Network) {
+	if len(adversary.TimestampManipulators()) == 0 {
+		return
+	}
+
+	for _, peer := range net.Peers {
+		timestampOutcomes[peer.ID] = newTimestampOutcome()
+	}
+	for _, peer := range net.Peers {
+		peerID := peer.ID
+		tangle := peer.Node.(multiverse.NodeInterface).Tangle()
+		tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID multiverse.MessageID, message *multiverse.Message, messageMetadata *multiverse.MessageMetadata) {
+			if message.Issuer != peerID {
+				return
+			}
+			timestampOutcomeMutex.Lock()
+			defer timestampOutcomeMutex.Unlock()
+			timestampOutcomes[peerID].issued++
+		}))
+		if network.IsAdversary(int(peerID)) {
+			continue
+		}
+		tangle.TimestampValidator.Events.MessageRejected.Attach(events.NewClosure(func(message *multiverse.Message, rule string) {
+			timestampOutcomeMutex.Lock()
+			defer timestampOutcomeMutex.Unlock()
+			timestampOutcomes[message.Issuer].rejectionsByRule[rule]++
+			if !timestampRejectedMessages[message.ID] {
+				timestampRejectedMessages[message.ID] = true
+				timestampOutcomes[message.Issuer].rejected++
+			}
+		}))
+		tangle.ApprovalManager.Events.MessageConfirmed.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			timestampOutcomeMutex.Lock()
+			defer timestampOutcomeMutex.Unlock()
+			if timestampDecidedMessages[message.ID] {
+				return
+			}
+			timestampDecidedMessages[message.ID] = true
+			timestampOutcomes[message.Issuer].confirmed++
+			timestampOutcomes[message.Issuer].acceptanceLatency += messageMetadata.ConfirmationTime().Sub(messageMetadata.ArrivalTime())
+		}))
+		tangle.ApprovalManager.Events.MessageOrphaned.Attach(events.NewClosure(func(message *multiverse.Message, messageMetadata *multiverse.MessageMetadata, weight uint64, messageIDCounter int64) {
+			timestampOutcomeMutex.Lock()
+			defer timestampOutcomeMutex.Unlock()
+			if timestampDecidedMessages[message.ID] {
+				return
+			}
+			timestampDecidedMessages[message.ID] = true
+			timestampOutcomes[message.Issuer].orphaned++
+		}))
+	}
+}
+
+// dumpTimestamps writes for every timestamp manipulator and for all honest nodes together how many of their messages
+// the honest nodes rejected, confirmed or orphaned, next to the mean RMC of the honest nodes at the end.
+func dumpTimestamps(net *network.Network) {
+	manipulators := adversary.TimestampManipulators()
+	if len(manipulators) == 0 {
+		return
+	}
+
+	header := []string{
+		"Node ID",
+		"Timestamp Shift Min (ns)",
+		"Timestamp Shift Max (ns)",
+		"Issued Messages",
+		"Rejected Messages",
+		"Drift Rejections",
+		"Parent Order Rejections",
+		"Parent Age Rejections",
+		"Confirmed Messages",
+		"Orphaned Messages",
+		"Mean Acceptance Latency (ns)",
+		"Mean Honest RMC",
+	}
+	rows := make(csvRows, 0)
+
+	honestRMC, honestNodesCount := 0.0, 0
+	for _, peer := range net.Peers {
+		if network.IsAdversary(int(peer.ID)) {
+			continue
+		}
+		storage := peer.Node.(multiverse.NodeInterface).Tangle().Storage
+		honestRMC += storage.RMC(storage.SlotIndex(time.Now()))
+		honestNodesCount++
+	}
+	meanHonestRMC := strconv.FormatFloat(honestRMC/math.Max(float64(honestNodesCount), 1), 'f', 6, 64)
+
+	addOutcome := func(nodeID string, shiftMin, shiftMax time.Duration, outcome *timestampOutcome) {
+		meanAcceptanceLatency := int64(0)
+		if outcome.confirmed > 0 {
+			meanAcceptanceLatency = outcome.acceptanceLatency.Nanoseconds() / outcome.confirmed
+		}
+		record := []string{
+			nodeID,
+			strconv.FormatInt(shiftMin.Nanoseconds(), 10),
+			strconv.FormatInt(shiftMax.Nanoseconds(), 10),
+			strconv.FormatInt(outcome.issued, 10),
+			strconv.FormatInt(outcome.rejected, 10),
+			strconv.FormatInt(outcome.rejectionsByRule[multiverse.TimestampDrift], 10),
+			strconv.FormatInt(outcome.rejectionsByRule[multiverse.TimestampParentOrder], 10),
+			strconv.FormatInt(outcome.rejectionsByRule[multiverse.TimestampParentAge], 10),
+			strconv.FormatInt(outcome.confirmed, 10),
+			strconv.FormatInt(outcome.orphaned, 10),
+			strconv.FormatInt(meanAcceptanceLatency, 10),
+			meanHonestRMC,
+		}
+		rows = append(rows, record)
+	}
+
+	timestampOutcomeMutex.Lock()
+	defer timestampOutcomeMutex.Unlock()
+	shiftMin := config.Params.TimestampShiftMin * time.Duration(config.Params.SlowdownFactor)
+	shiftMax := config.Params.TimestampShiftMax * time.Duration(config.Params.SlowdownFactor)
+	for _, manipulator := range manipulators {
+		peerID := manipulator.Peer().ID
+		addOutcome(strconv.FormatInt(int64(peerID), 10), shiftMin, shiftMax, timestampOutcomes[peerID])
+	}
+	honestNodes := newTimestampOutcome()
+	for _, peer := range net.Peers {
+		if !network.IsAdversary(int(peer.ID)) {
+			honestNodes.add(timestampOutcomes[peer.ID])
+		}
+	}
+	addOutcome("Honest", 0, 0, honestNodes)
+	writeCSV(path.Join(config.Params.GeneralOutputDir, "timestamps.csv"), header, rows)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////